@@ -3,7 +3,16 @@ module example.com/gocab
 go 1.19
 
 require (
-	github.com/jessevdk/go-flags v1.5.0 // indirect
-	github.com/mattn/go-sqlite3 v1.14.17 // indirect
-	golang.org/x/sys v0.0.0-20210320140829-1e4c9ba3b0c4 // indirect
+	github.com/jessevdk/go-flags v1.5.0
+	github.com/mattn/go-sqlite3 v1.14.17
+	golang.org/x/text v0.14.0
+	google.golang.org/grpc v1.56.3
+	google.golang.org/protobuf v1.30.0
+)
+
+require (
+	github.com/golang/protobuf v1.5.3 // indirect
+	golang.org/x/net v0.9.0 // indirect
+	golang.org/x/sys v0.7.0 // indirect
+	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
 )
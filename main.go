@@ -9,6 +9,11 @@ import (
 	"github.com/jessevdk/go-flags"
 )
 
+// options are shared by every command
+type options struct {
+	User string `short:"u" long:"user" env:"GOCAB_USER" default:"default" description:"user whose progress is used"`
+}
+
 func main() {
 	if len(os.Args) < 2 {
 		print("expected 'add', 'update' or 'quiz' subcommands")
@@ -38,19 +43,48 @@ func main() {
 	}
 
 	defer repository.Close()
-	service := pkg.NewService(repository)
+
+	// The user is needed to create the commands, so the shared options are
+	// read ahead of the commands themselves
+	opts := &options{}
+	flags.NewParser(opts, flags.IgnoreUnknown).ParseArgs(os.Args[1:])
+
+	service, err := pkg.NewService(repository).ForUser(opts.User)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 
 	parser := flags.NewNamedParser("gocab", flags.Default)
+	parser.AddGroup("Application Options", "", opts)
 
 	addCommand := pkg.CreateAddCommand(service)
 	updateCommand := pkg.CreateUpdateCommand(service)
+	fillCommand := pkg.CreateFillCommand(service, os.Stdin, os.Stdout)
+	mineCommand := pkg.CreateMineCommand(service, os.Stdin, os.Stdout)
 	quizCommand := pkg.CreateQuizCommand(service, os.Stdin, os.Stdout)
 	importCommand := pkg.CreateImportCommand(service, os.Stdout)
+	configCommand := pkg.CreateConfigCommand(service, os.Stdout)
+	listCommand := pkg.CreateListCommand(service, os.Stdout)
+	deleteCommand := pkg.CreateDeleteCommand(service, os.Stdin, os.Stdout)
+	searchCommand := pkg.CreateSearchCommand(service, os.Stdout)
+	exportCommand := pkg.CreateExportCommand(service, os.Stdout)
+	serveCommand := pkg.CreateServeCommand(service, repository, os.Stdout)
+	tokenCommand := pkg.CreateTokenCommand(repository, opts.User, os.Stdout)
 
 	parser.AddCommand("add", "add new word", "", addCommand)
 	parser.AddCommand("update", "update word", "", updateCommand)
+	parser.AddCommand("fill", "fill in the meaning of draft words", "", fillCommand)
+	parser.AddCommand("mine", "find new words in a text or subtitles", "", mineCommand)
 	parser.AddCommand("quiz", "start quiz", "", quizCommand)
 	parser.AddCommand("import", "import words", "", importCommand)
+	parser.AddCommand("config", "configure a language", "", configCommand)
+	parser.AddCommand("list", "list words", "", listCommand)
+	parser.AddCommand("delete", "delete words", "", deleteCommand)
+	parser.AddCommand("search", "search words", "", searchCommand)
+	parser.AddCommand("export", "export words", "", exportCommand)
+	parser.AddCommand("serve", "serve the json api and the quiz web ui", "", serveCommand)
+	parser.AddCommand("token", "manage api tokens", "", tokenCommand)
 
 	parser.Parse()
 }
@@ -0,0 +1,515 @@
+package pkg
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidPackage      = errors.New("not an anki package")
+	ErrInvalidFieldMapping = errors.New("field mappings must be written as column=field")
+)
+
+// ANKI_FIELD_SEPARATOR separates the fields of a note
+const ANKI_FIELD_SEPARATOR = "\x1f"
+
+// Fields of the note type of exported decks, named after the columns they
+// hold so that importing the deck back needs no mapping
+var ankiFields = []string{"Word", "Meaning", "Pronunciation", "Example"}
+
+// AnkiImporter reads the notes of an Anki package (.apkg), a zip holding
+// the deck's SQLite collection
+type AnkiImporter struct {
+	Lang string
+
+	// Fields maps word columns to the name or position, starting at 1, of
+	// the note field holding them. Fields named after a column are mapped
+	// to it, and otherwise the first two fields hold the word and meaning.
+	Fields map[string]string
+}
+
+func NewAnkiImporter(lang string) *AnkiImporter {
+	return &AnkiImporter{Lang: lang, Fields: make(map[string]string)}
+}
+
+// ParseFieldMappings reads mappings written as "meaning=Back"
+func ParseFieldMappings(mappings []string) (map[string]string, error) {
+	fields := make(map[string]string)
+
+	for _, mapping := range mappings {
+		column, field, found := strings.Cut(mapping, "=")
+		column = strings.ToLower(strings.TrimSpace(column))
+
+		if !found || field == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidFieldMapping, mapping)
+		}
+
+		switch column {
+		case COLUMN_WORD, COLUMN_ARTICLE, COLUMN_MEANING, COLUMN_PRONUNCIATION, COLUMN_EXAMPLE:
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownColumn, column)
+		}
+
+		fields[column] = strings.TrimSpace(field)
+	}
+
+	return fields, nil
+}
+
+type ankiModel struct {
+	Fields []struct {
+		Name string `json:"name"`
+		Ord  int    `json:"ord"`
+	} `json:"flds"`
+}
+
+// Read returns the words of every note, notes without a word being
+// rejected along with their position in the collection
+func (i *AnkiImporter) Read(reader io.Reader) ([]*ImportedWord, []*LineError, error) {
+	collection, err := extractCollection(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer os.RemoveAll(filepath.Dir(collection))
+
+	conn, err := sql.Open("sqlite3", collection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer conn.Close()
+
+	var encoded string
+	if err := conn.QueryRow("SELECT models FROM col").Scan(&encoded); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidPackage, err)
+	}
+
+	models := make(map[string]*ankiModel)
+	if err := json.Unmarshal([]byte(encoded), &models); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidPackage, err)
+	}
+
+	rows, err := conn.Query("SELECT id, mid, tags, flds FROM notes ORDER BY id")
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidPackage, err)
+	}
+
+	defer rows.Close()
+
+	words := make([]*ImportedWord, 0)
+	failed := make([]*LineError, 0)
+
+	for position := 1; rows.Next(); position++ {
+		var id, mid int64
+		var tags, fields string
+
+		if err := rows.Scan(&id, &mid, &tags, &fields); err != nil {
+			return nil, nil, err
+		}
+
+		model, ok := models[strconv.FormatInt(mid, 10)]
+		if !ok {
+			failed = append(failed, &LineError{position, fmt.Errorf("unknown note type %d", mid)})
+			continue
+		}
+
+		word, err := i.parseNote(model, strings.Split(fields, ANKI_FIELD_SEPARATOR))
+		if err != nil {
+			failed = append(failed, &LineError{position, err})
+			continue
+		}
+
+		// Note ids are the time they were created at, in milliseconds
+		word.Added = time.UnixMilli(id)
+		word.Tags = strings.Fields(tags)
+
+		words = append(words, &ImportedWord{position, word})
+	}
+
+	return words, failed, rows.Err()
+}
+
+func (i *AnkiImporter) parseNote(model *ankiModel, values []string) (*Word, error) {
+	indexes := make(map[string]int)
+	for _, field := range model.Fields {
+		indexes[strings.ToLower(field.Name)] = field.Ord
+	}
+
+	// Note types without a mapped field leave its column empty
+	field := func(column string) string {
+		index, ok := indexes[column]
+
+		if name, mapped := i.Fields[column]; mapped {
+			index, ok = indexes[strings.ToLower(name)]
+			if position, err := strconv.Atoi(name); err == nil {
+				index, ok = position-1, true
+			}
+		} else if !ok && column == COLUMN_WORD {
+			index, ok = 0, true
+		} else if !ok && column == COLUMN_MEANING {
+			index, ok = 1, true
+		}
+
+		if !ok || index < 0 || index >= len(values) {
+			return ""
+		}
+
+		return stripHTML(values[index])
+	}
+
+	word := &Word{
+		Lang:          i.Lang,
+		Word:          strings.Join(strings.Fields(field(COLUMN_WORD)), " "),
+		Article:       strings.TrimSpace(field(COLUMN_ARTICLE)),
+		Pronunciation: strings.TrimSpace(field(COLUMN_PRONUNCIATION)),
+		Example:       strings.Join(strings.Fields(field(COLUMN_EXAMPLE)), " "),
+		Meanings:      make([]Meaning, 0),
+	}
+
+	// Meanings are written on their own line or separated by semicolons
+	for _, line := range strings.Split(field(COLUMN_MEANING), "\n") {
+		word.Meanings = append(word.Meanings, ParseMeanings(line)...)
+	}
+
+	if word.Word == "" {
+		return nil, ErrMissingWord
+	}
+
+	return word, nil
+}
+
+var lineBreaks = regexp.MustCompile(`(?i)<br\s*/?>|</div>|</p>|</li>`)
+var htmlTags = regexp.MustCompile(`<[^>]*>`)
+
+// stripHTML turns the HTML of a note field into text, keeping line breaks
+func stripHTML(value string) string {
+	value = lineBreaks.ReplaceAllString(value, "\n")
+	value = htmlTags.ReplaceAllString(value, "")
+	value = html.UnescapeString(value)
+	return strings.ReplaceAll(value, "\u00a0", " ")
+}
+
+// extractCollection writes the collection of a package to a temporary
+// directory
+func extractCollection(reader io.Reader) (string, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidPackage, err)
+	}
+
+	// Packages exported for recent versions also hold a legacy collection
+	var collection *zip.File
+	for _, file := range archive.File {
+		if file.Name == "collection.anki21" || (file.Name == "collection.anki2" && collection == nil) {
+			collection = file
+		}
+	}
+
+	if collection == nil {
+		return "", fmt.Errorf("%w: no collection found", ErrInvalidPackage)
+	}
+
+	source, err := collection.Open()
+	if err != nil {
+		return "", err
+	}
+
+	defer source.Close()
+
+	return writeTempFile(source, "collection.anki2")
+}
+
+// writeTempFile copies a database to a new temporary directory, the driver
+// only reading databases from files. The directory is for the caller to
+// remove.
+func writeTempFile(reader io.Reader, name string) (string, error) {
+	dir, err := os.MkdirTemp("", "gocab-")
+	if err != nil {
+		return "", err
+	}
+
+	filename := filepath.Join(dir, name)
+
+	file, err := os.Create(filename)
+	if err == nil {
+		_, err = io.Copy(file, reader)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+
+	return filename, nil
+}
+
+// ankiSchema is the schema of collections Anki 2.1 still imports
+const ankiSchema = `
+    CREATE TABLE col (
+        id INTEGER PRIMARY KEY, crt INTEGER NOT NULL, mod INTEGER NOT NULL, scm INTEGER NOT NULL,
+        ver INTEGER NOT NULL, dty INTEGER NOT NULL, usn INTEGER NOT NULL, ls INTEGER NOT NULL,
+        conf TEXT NOT NULL, models TEXT NOT NULL, decks TEXT NOT NULL, dconf TEXT NOT NULL, tags TEXT NOT NULL
+    );
+
+    CREATE TABLE notes (
+        id INTEGER PRIMARY KEY, guid TEXT NOT NULL, mid INTEGER NOT NULL, mod INTEGER NOT NULL,
+        usn INTEGER NOT NULL, tags TEXT NOT NULL, flds TEXT NOT NULL, sfld INTEGER NOT NULL,
+        csum INTEGER NOT NULL, flags INTEGER NOT NULL, data TEXT NOT NULL
+    );
+
+    CREATE TABLE cards (
+        id INTEGER PRIMARY KEY, nid INTEGER NOT NULL, did INTEGER NOT NULL, ord INTEGER NOT NULL,
+        mod INTEGER NOT NULL, usn INTEGER NOT NULL, type INTEGER NOT NULL, queue INTEGER NOT NULL,
+        due INTEGER NOT NULL, ivl INTEGER NOT NULL, factor INTEGER NOT NULL, reps INTEGER NOT NULL,
+        lapses INTEGER NOT NULL, left INTEGER NOT NULL, odue INTEGER NOT NULL, odid INTEGER NOT NULL,
+        flags INTEGER NOT NULL, data TEXT NOT NULL
+    );
+
+    CREATE TABLE revlog (
+        id INTEGER PRIMARY KEY, cid INTEGER NOT NULL, usn INTEGER NOT NULL, ease INTEGER NOT NULL,
+        ivl INTEGER NOT NULL, lastIvl INTEGER NOT NULL, factor INTEGER NOT NULL, time INTEGER NOT NULL,
+        type INTEGER NOT NULL
+    );
+
+    CREATE TABLE graves (usn INTEGER NOT NULL, oid INTEGER NOT NULL, type INTEGER NOT NULL);
+
+    CREATE INDEX ix_notes_usn ON notes (usn);
+    CREATE INDEX ix_cards_usn ON cards (usn);
+    CREATE INDEX ix_revlog_usn ON revlog (usn);
+    CREATE INDEX ix_cards_nid ON cards (nid);
+    CREATE INDEX ix_cards_sched ON cards (did, queue, due);
+    CREATE INDEX ix_revlog_cid ON revlog (cid);
+    CREATE INDEX ix_notes_csum ON notes (csum);
+`
+
+// exportAnki writes a package holding a deck named after the language,
+// words reviewed with SM-2 keeping their interval and due date
+func exportAnki(writer io.Writer, words []*Word) error {
+	dir, err := os.MkdirTemp("", "gocab-")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "collection.anki2")
+	if err := writeCollection(filename, words); err != nil {
+		return err
+	}
+
+	collection, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+
+	defer collection.Close()
+
+	archive := zip.NewWriter(writer)
+
+	file, err := archive.Create("collection.anki2")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, collection); err != nil {
+		return err
+	}
+
+	// Media files are listed in a JSON object, there are none
+	media, err := archive.Create("media")
+	if err != nil {
+		return err
+	}
+
+	if _, err := media.Write([]byte("{}")); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+func writeCollection(filename string, words []*Word) error {
+	conn, err := sql.Open("sqlite3", filename)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	if _, err := conn.Exec(ankiSchema); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	created := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	modelID := now.UnixMilli()
+	deckID := modelID + 1
+
+	deck := "gocab"
+	if len(words) > 0 {
+		deck = words[0].Lang
+	}
+
+	conf, models, decks, dconf, err := ankiConfiguration(modelID, deckID, deck, now)
+	if err != nil {
+		return err
+	}
+
+	tx, err := conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO col VALUES (1, ?, ?, ?, 11, 0, 0, 0, ?, ?, ?, ?, '{}')",
+		created.Unix(), now.UnixMilli(), now.UnixMilli(), conf, models, decks, dconf,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for i, word := range words {
+		id := modelID + int64(i) + 2
+
+		values := []string{html.EscapeString(word.WithArticle()), html.EscapeString(word.Meaning()), html.EscapeString(word.Pronunciation), html.EscapeString(word.Example)}
+
+		// Anki tags cannot hold spaces and are listed between spaces
+		tags := ""
+		for _, tag := range word.Tags {
+			tags += " " + strings.Join(strings.Fields(tag), "_")
+		}
+		if tags != "" {
+			tags += " "
+		}
+
+		_, err := tx.Exec(
+			"INSERT INTO notes VALUES (?, ?, ?, ?, -1, ?, ?, ?, ?, 0, '')",
+			id, ankiGUID(word), modelID, now.Unix(), tags, strings.Join(values, ANKI_FIELD_SEPARATOR), values[0], ankiChecksum(values[0]),
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		// New cards are due in the order they are listed, reviewed ones on
+		// their due day counted from the collection's creation
+		kind, due, factor := 0, int64(i+1), 0
+		if word.Repetitions > 0 && !word.Due.IsZero() && word.Ease > 0 {
+			kind, due, factor = 2, int64(word.Due.Sub(created).Hours()/24), int(word.Ease*1000)
+		}
+
+		_, err = tx.Exec(
+			"INSERT INTO cards VALUES (?, ?, ?, 0, ?, -1, ?, ?, ?, ?, ?, ?, 0, 0, 0, 0, 0, '')",
+			id, id, deckID, now.Unix(), kind, kind, due, word.Interval, factor, word.Repetitions,
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ankiConfiguration returns the collection settings, note types, decks and
+// deck options stored as JSON in the col table
+func ankiConfiguration(modelID, deckID int64, name string, now time.Time) (string, string, string, string, error) {
+	fields := make([]map[string]any, len(ankiFields))
+	for i, field := range ankiFields {
+		fields[i] = map[string]any{"name": field, "ord": i, "sticky": false, "rtl": false, "font": "Arial", "size": 20, "media": []any{}}
+	}
+
+	model := map[string]any{
+		"id": modelID, "name": "gocab", "type": 0, "mod": now.Unix(), "usn": -1, "sortf": 0, "did": deckID,
+		"flds": fields,
+		"tmpls": []map[string]any{{
+			"name": "Card 1", "ord": 0, "did": nil, "bqfmt": "", "bafmt": "",
+			"qfmt": "{{Word}}",
+			"afmt": "{{FrontSide}}<hr id=answer>{{Meaning}}<br>{{Pronunciation}}<br><i>{{Example}}</i>",
+		}},
+		"css":       ".card { font-family: arial; font-size: 20px; text-align: center; }",
+		"latexPre":  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage[utf8]{inputenc}\n\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n",
+		"latexPost": "\\end{document}",
+		"req":       []any{[]any{0, "any", []int{0}}},
+		"tags":      []any{},
+		"vers":      []any{},
+	}
+
+	deck := func(id int64, name string) map[string]any {
+		return map[string]any{
+			"id": id, "name": name, "mod": now.Unix(), "usn": -1, "desc": "", "dyn": 0, "conf": 1,
+			"collapsed": false, "browserCollapsed": false, "extendNew": 10, "extendRev": 50,
+			"newToday": []int{0, 0}, "revToday": []int{0, 0}, "lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+		}
+	}
+
+	options := map[string]any{
+		"id": 1, "name": "Default", "mod": 0, "usn": 0, "maxTaken": 60, "autoplay": true, "timer": 0, "replayq": true, "dyn": false,
+		"new":   map[string]any{"delays": []int{1, 10}, "ints": []int{1, 4, 7}, "initialFactor": 2500, "order": 1, "perDay": 20, "bury": false, "separate": true},
+		"rev":   map[string]any{"perDay": 200, "ease4": 1.3, "fuzz": 0.05, "maxIvl": 36500, "ivlFct": 1, "bury": false, "minSpace": 1},
+		"lapse": map[string]any{"delays": []int{10}, "mult": 0, "minInt": 1, "leechFails": 8, "leechAction": 0},
+	}
+
+	conf := map[string]any{
+		"nextPos": 1, "estTimes": true, "activeDecks": []int64{deckID}, "sortType": "noteFld", "timeLim": 0,
+		"sortBackwards": false, "addToCur": true, "curDeck": deckID, "newSpread": 0, "dueCounts": true,
+		"curModel": strconv.FormatInt(modelID, 10), "collapseTime": 1200,
+	}
+
+	values := []any{
+		conf,
+		map[string]any{strconv.FormatInt(modelID, 10): model},
+		map[string]any{"1": deck(1, "Default"), strconv.FormatInt(deckID, 10): deck(deckID, name)},
+		map[string]any{"1": options},
+	}
+
+	encoded := make([]string, len(values))
+	for i, value := range values {
+		content, err := json.Marshal(value)
+		if err != nil {
+			return "", "", "", "", err
+		}
+		encoded[i] = string(content)
+	}
+
+	return encoded[0], encoded[1], encoded[2], encoded[3], nil
+}
+
+// ankiGUID identifies the note of a word, so that importing a deck exported
+// again updates the notes imported before
+func ankiGUID(word *Word) string {
+	sum := sha1.Sum([]byte("gocab:" + word.Lang + ":" + word.Word))
+	return base64.RawStdEncoding.EncodeToString(sum[:8])
+}
+
+// ankiChecksum is the first 8 hexadecimal digits of the SHA-1 of the sort
+// field, which Anki uses to find duplicates
+func ankiChecksum(field string) int64 {
+	sum := sha1.Sum([]byte(stripHTML(field)))
+	return int64(binary.BigEndian.Uint32(sum[:4]))
+}
@@ -0,0 +1,166 @@
+package pkg_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"example.com/gocab/pkg"
+	"github.com/jessevdk/go-flags"
+)
+
+func TestAnkiImporter(t *testing.T) {
+	read := func(t *testing.T, importer *pkg.AnkiImporter) ([]*pkg.ImportedWord, []*pkg.LineError) {
+		t.Helper()
+
+		file, err := os.Open("testdata/deck.apkg")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		defer file.Close()
+
+		words, failed, err := importer.Read(file)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		return words, failed
+	}
+
+	t.Run("default fields", func(t *testing.T) {
+		words, failed := read(t, pkg.NewAnkiImporter("german"))
+
+		if len(words) != 3 {
+			t.Fatalf("expected 3 words, got %d", len(words))
+		}
+
+		haus := words[0].Word
+		if haus.Word != "das Haus" || haus.Lang != "german" || haus.Meaning() != "House; Home (my place)" {
+			t.Errorf("expected das Haus meaning House; Home (my place), got %s meaning %s", haus.Word, haus.Meaning())
+		}
+
+		if strings.Join(haus.Tags, " ") != "noun building" {
+			t.Errorf("expected tags noun and building, got %v", haus.Tags)
+		}
+
+		if !haus.Added.Equal(time.UnixMilli(1600000000100)) {
+			t.Errorf("expected note creation time, got %v", haus.Added)
+		}
+
+		gehen := words[1].Word
+		if gehen.Word != "gehen" || gehen.Meaning() != "to go; to walk" {
+			t.Errorf("expected html to be stripped, got %q meaning %q", gehen.Word, gehen.Meaning())
+		}
+
+		mann := words[2].Word
+		if mann.Word != "Mann" || mann.Meaning() != "Man; Husband (spouse)" || mann.Example != "" || words[2].Line != 4 {
+			t.Errorf("expected first fields of other note types, got %v on line %d", mann, words[2].Line)
+		}
+
+		if len(failed) != 1 || failed[0].Line != 3 || !errors.Is(failed[0], pkg.ErrMissingWord) {
+			t.Errorf("expected note 3 to be rejected, got %v", failed)
+		}
+	})
+
+	t.Run("mapped fields", func(t *testing.T) {
+		fields, err := pkg.ParseFieldMappings([]string{"example=Beispiel", "pronunciation=4"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		importer := pkg.NewAnkiImporter("german")
+		importer.Fields = fields
+
+		words, _ := read(t, importer)
+		if len(words) != 3 {
+			t.Fatalf("expected 3 words, got %d", len(words))
+		}
+
+		if mann := words[2].Word; mann.Example != "Der Mann & die Frau" || mann.Pronunciation != "man" {
+			t.Errorf("expected mapped example and pronunciation, got %q and %q", mann.Example, mann.Pronunciation)
+		}
+
+		if haus := words[0].Word; haus.Example != "" {
+			t.Errorf("expected no example for note types without the field, got %q", haus.Example)
+		}
+	})
+
+	t.Run("invalid mappings", func(t *testing.T) {
+		cases := map[string]error{
+			"meaning":      pkg.ErrInvalidFieldMapping,
+			"colour=Farbe": pkg.ErrUnknownColumn,
+		}
+
+		for mapping, expected := range cases {
+			if _, err := pkg.ParseFieldMappings([]string{mapping}); !errors.Is(err, expected) {
+				t.Errorf("expected %v for %q, got %v", expected, mapping, err)
+			}
+		}
+	})
+
+	t.Run("invalid package", func(t *testing.T) {
+		_, _, err := pkg.NewAnkiImporter("german").Read(strings.NewReader("word,meaning\n"))
+		if !errors.Is(err, pkg.ErrInvalidPackage) {
+			t.Errorf("expected %v, got %v", pkg.ErrInvalidPackage, err)
+		}
+	})
+}
+
+func TestAnkiExport(t *testing.T) {
+	repository := newSqliteRepository(t)
+	service := pkg.NewService(repository)
+
+	service.AddWord(&pkg.Word{Lang: "german", Word: "das Haus", Meanings: pkg.ParseMeanings("House; Home (my place)"), Example: "Das <Haus>", Tags: []string{"noun", "building"}})
+	service.AddWord(&pkg.Word{Lang: "german", Word: "gehen", Meanings: pkg.ParseMeanings("to go, to walk"), Pronunciation: "ˈɡeːən", Tags: []string{"verb"}})
+
+	filename := path.Join(t.TempDir(), "german.apkg")
+	cmd := pkg.CreateExportCommand(service, bytes.NewBuffer(nil))
+
+	if _, err := flags.ParseArgs(cmd, []string{"-l", "german", "-f", filename, "--format", "apkg"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := cmd.Execute([]string{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	imported := newSqliteRepository(t)
+	writer := bytes.NewBuffer(nil)
+	importCmd := pkg.CreateImportCommand(pkg.NewService(imported), writer)
+
+	if _, err := flags.ParseArgs(importCmd, []string{"-l", "german", "-f", filename, "--format", "apkg"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := importCmd.Execute([]string{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if writer.String() != "imported 2 word(s)\n" {
+		t.Errorf("expected 2 imported words, got %q", writer.String())
+	}
+
+	expected, _ := repository.ListWords("german", nil, "", pkg.SORT_WORD, 0)
+	found, _ := imported.ListWords("german", nil, "", pkg.SORT_WORD, 0)
+
+	if len(found) != len(expected) {
+		t.Fatalf("expected %d words, got %d", len(expected), len(found))
+	}
+
+	for i := range expected {
+		e, f := expected[i], found[i]
+
+		if f.Word != e.Word || f.Article != e.Article || f.Meaning() != e.Meaning() || f.Example != e.Example || f.Pronunciation != e.Pronunciation {
+			t.Errorf("expected %v, got %v", e, f)
+		}
+
+		if strings.Join(f.Tags, ",") != strings.Join(e.Tags, ",") {
+			t.Errorf("expected tags %v, got %v", e.Tags, f.Tags)
+		}
+	}
+}
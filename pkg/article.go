@@ -0,0 +1,70 @@
+package pkg
+
+import (
+	"strings"
+)
+
+// Grammatical genders
+const MASCULINE = "masculine"
+const FEMININE = "feminine"
+const NEUTER = "neuter"
+
+// articles maps the definite articles of each language to their gender
+var articles = map[string]map[string]string{
+	"german": {
+		"der": MASCULINE,
+		"die": FEMININE,
+		"das": NEUTER,
+	},
+	"french": {
+		"le": MASCULINE,
+		"la": FEMININE,
+	},
+	"spanish": {
+		"el": MASCULINE,
+		"la": FEMININE,
+	},
+	"portuguese": {
+		"o": MASCULINE,
+		"a": FEMININE,
+	},
+	"italian": {
+		"il": MASCULINE,
+		"lo": MASCULINE,
+		"la": FEMININE,
+	},
+}
+
+// Tags changing how a leading article is split off an added word: nouns
+// are split even when longer than a word, as "der Weiße Hai", and phrases
+// are kept as typed
+const TAG_NOUN = "noun"
+const TAG_PHRASE = "phrase"
+
+// SplitArticle separates a leading article from a single noun, as in
+// "das Haus", returning an empty article when text does not start with one
+// or is a longer phrase, as "die ganze Zeit"
+func SplitArticle(lang, text string) (string, string) {
+	return splitArticle(lang, text, false)
+}
+
+// splitArticle separates a leading article, from phrases too when asked
+func splitArticle(lang, text string, phrases bool) (string, string) {
+	text = strings.TrimSpace(text)
+
+	fields := strings.SplitN(text, " ", 2)
+	if len(fields) < 2 {
+		return "", text
+	}
+
+	if _, ok := articles[strings.ToLower(lang)][strings.ToLower(fields[0])]; !ok {
+		return "", text
+	}
+
+	rest := strings.TrimSpace(fields[1])
+	if !phrases && strings.ContainsAny(rest, " \t") {
+		return "", text
+	}
+
+	return fields[0], rest
+}
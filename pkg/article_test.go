@@ -0,0 +1,257 @@
+package pkg_test
+
+import (
+	"strings"
+	"testing"
+
+	"example.com/gocab/pkg"
+)
+
+func TestSplitArticle(t *testing.T) {
+	cases := []struct {
+		lang, text, article, word string
+	}{
+		{"german", "das Haus", "das", "Haus"},
+		{"german", "Der  Mann", "Der", "Mann"},
+		{"german", "Haus", "", "Haus"},
+		{"german", "die", "", "die"},
+		{"german", "le chat", "", "le chat"},
+		{"french", "le chat", "le", "chat"},
+		{"german", "die ganze Zeit", "", "die ganze Zeit"},
+		{"portuguese", "a partir de", "", "a partir de"},
+		{"italian", "la casa", "la", "casa"},
+	}
+
+	for _, c := range cases {
+		article, word := pkg.SplitArticle(c.lang, c.text)
+		if article != c.article || word != c.word {
+			t.Errorf("expected %q to be split in %q and %q, got %q and %q", c.text, c.article, c.word, article, word)
+		}
+	}
+}
+
+func TestArticles(t *testing.T) {
+	haus := &pkg.Word{Lang: "german", Word: "Haus", Article: "das", Meanings: pkg.ParseMeanings("House")}
+
+	check := func(articles, answer string) pkg.Result {
+		question := &pkg.Question{Type: pkg.ENGLISH_TO_FOREIGN, Word: haus, Answer: answer, Articles: articles}
+		return question.Check()
+	}
+
+	t.Run("gender", func(t *testing.T) {
+		if gender := haus.Gender(); gender != pkg.NEUTER {
+			t.Errorf("expected %s, got %s", pkg.NEUTER, gender)
+		}
+
+		if text := haus.WithArticle(); text != "das Haus" {
+			t.Errorf("expected das Haus, got %s", text)
+		}
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		for _, answer := range []string{"Haus", "das Haus", "der Haus"} {
+			if result := check(pkg.ARTICLES_IGNORE, answer); result != pkg.EXACT {
+				t.Errorf("expected %q to be exact, got %v", answer, result)
+			}
+		}
+	})
+
+	t.Run("require", func(t *testing.T) {
+		cases := []struct {
+			answer string
+			result pkg.Result
+		}{
+			{"das Haus", pkg.EXACT},
+			{"Das haus", pkg.EXACT},
+			{"der Haus", pkg.WRONG_ARTICLE},
+			{"Haus", pkg.WRONG_ARTICLE},
+			{"das Auto", pkg.WRONG},
+		}
+
+		for _, c := range cases {
+			if result := check(pkg.ARTICLES_REQUIRE, c.answer); result != c.result {
+				t.Errorf("expected %q to be %v, got %v", c.answer, c.result, result)
+			}
+		}
+	})
+
+	t.Run("ask", func(t *testing.T) {
+		question := pkg.NewArticleQuestion(haus)
+
+		if !strings.Contains(question.Text(), "article of Haus") {
+			t.Errorf("expected article to be asked, got %q", question.Text())
+		}
+
+		question.Answer = "das\n"
+		if result := question.Check(); result != pkg.EXACT {
+			t.Errorf("expected exact, got %v", result)
+		}
+
+		question.Answer = "die\n"
+		if result := question.Check(); result != pkg.WRONG {
+			t.Errorf("expected wrong, got %v", result)
+		}
+	})
+
+	t.Run("summary", func(t *testing.T) {
+		question := &pkg.Question{Type: pkg.ENGLISH_TO_FOREIGN, Word: haus, Answer: "der Haus", Articles: pkg.ARTICLES_REQUIRE}
+
+		summary := &pkg.Summary{Total: 1}
+		summary.WrongArticle(question)
+
+		expected := "\nTotal: 1, Correct: 0, Wrong article: 1, Mistakes: 0, Performance: 0%\nder Haus -> das Haus (wrong article)\n"
+		if summary.String() != expected {
+			t.Errorf("expected %q, got %q", expected, summary.String())
+		}
+	})
+}
+
+func TestArticleService(t *testing.T) {
+	t.Run("split on add", func(t *testing.T) {
+		repository := pkg.NewInMemoryRepository()
+		service := pkg.NewService(repository)
+
+		word, err := service.AddWord(&pkg.Word{Lang: "german", Word: "das Haus", Meanings: pkg.ParseMeanings("House")})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if word.Word != "Haus" || word.Article != "das" {
+			t.Errorf("expected Haus with article das, got %q and %q", word.Word, word.Article)
+		}
+
+		if _, err := service.UpdateWord(&pkg.Word{Lang: "german", Word: "das Haus", Meanings: pkg.ParseMeanings("Home")}); err != nil {
+			t.Errorf("expected update with article to find the word, got %v", err)
+		}
+	})
+
+	t.Run("phrases", func(t *testing.T) {
+		service := pkg.NewService(pkg.NewInMemoryRepository())
+
+		cases := []struct {
+			word                *pkg.Word
+			article, registered string
+		}{
+			{&pkg.Word{Lang: "german", Word: "die ganze Zeit"}, "", "die ganze Zeit"},
+			{&pkg.Word{Lang: "portuguese", Word: "a partir de"}, "", "a partir de"},
+			{&pkg.Word{Lang: "german", Word: "der Weiße Hai", Tags: []string{pkg.TAG_NOUN}}, "der", "Weiße Hai"},
+			{&pkg.Word{Lang: "german", Word: "die Hard", Tags: []string{pkg.TAG_PHRASE}}, "", "die Hard"},
+		}
+
+		for _, c := range cases {
+			c.word.Meanings = pkg.ParseMeanings("meaning")
+
+			word, err := service.AddWord(c.word)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if word.Word != c.registered || word.Article != c.article {
+				t.Errorf("expected %q with article %q, got %q and %q", c.registered, c.article, word.Word, word.Article)
+			}
+
+			if exists, _ := service.HasWord(c.word.Lang, c.word.Word); !exists {
+				t.Errorf("should find %q as typed", c.word.Word)
+			}
+		}
+
+		questions, err := service.CreateQuiz("german", nil, pkg.QuizOptions{Articles: pkg.ARTICLES_ASK})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		for _, question := range questions {
+			if question.Type == pkg.ARTICLE && question.Word.Word != "Weiße Hai" {
+				t.Errorf("expected no article to be asked for %q", question.Word.Word)
+			}
+		}
+
+		if err := service.DeleteWord("german", "die ganze Zeit"); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("noun phrase answer", func(t *testing.T) {
+		hai := &pkg.Word{Lang: "german", Word: "Weiße Hai", Article: "der", Meanings: pkg.ParseMeanings("Great white shark")}
+		question := &pkg.Question{Type: pkg.ENGLISH_TO_FOREIGN, Word: hai, Answer: "der Weiße Hai", Articles: pkg.ARTICLES_REQUIRE}
+
+		if result := question.Check(); result != pkg.EXACT {
+			t.Errorf("expected exact, got %v", result)
+		}
+	})
+
+	t.Run("ask", func(t *testing.T) {
+		repository := pkg.NewInMemoryRepository()
+		service := pkg.NewService(repository)
+
+		service.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Article: "das", Meanings: pkg.ParseMeanings("House")})
+		service.AddWord(&pkg.Word{Lang: "german", Word: "gehen", Meanings: pkg.ParseMeanings("to go")})
+
+		questions, err := service.CreateQuiz("german", nil, pkg.QuizOptions{Articles: pkg.ARTICLES_ASK})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(questions) != 3 {
+			t.Fatalf("expected 3 questions, got %d", len(questions))
+		}
+
+		summary := &pkg.Summary{Total: len(questions)}
+		for _, question := range questions {
+			if question.Type == pkg.ARTICLE {
+				question.Answer = "der"
+				summary.Wrong(question)
+			} else {
+				question.Answer = question.ExpectedAnswer()
+				summary.Correct(question)
+			}
+		}
+
+		if err := service.SaveResult(summary); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		words, _ := repository.FindWords("german", nil)
+		for _, word := range words {
+			// Only the question asking the word schedules it
+			if word.Repetitions != 1 {
+				t.Errorf("expected %s to be reviewed once, got %d repetitions", word.Word, word.Repetitions)
+			}
+		}
+
+		reviews, _ := repository.FindReviews("german", "Haus")
+		if len(reviews) != 2 {
+			t.Errorf("expected the article answer to be recorded, got %d reviews", len(reviews))
+		}
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		repository := pkg.NewInMemoryRepository()
+		service := pkg.NewService(repository)
+		service.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House")})
+
+		if _, err := service.CreateQuiz("german", nil, pkg.QuizOptions{Articles: "guess"}); err != pkg.ErrUnknownArticles {
+			t.Errorf("expected %v, got %v", pkg.ErrUnknownArticles, err)
+		}
+
+		if _, err := service.CreateQuiz("french", nil, pkg.QuizOptions{Articles: "guess"}); err != pkg.ErrUnknownArticles {
+			t.Errorf("expected %v without words, got %v", pkg.ErrUnknownArticles, err)
+		}
+	})
+
+	t.Run("sqlite", func(t *testing.T) {
+		repository := newSqliteRepository(t)
+		service := pkg.NewService(repository)
+
+		service.AddWord(&pkg.Word{Lang: "german", Word: "die Frau", Meanings: pkg.ParseMeanings("Woman")})
+
+		words, err := repository.ListWords("german", nil, "", pkg.SORT_WORD, 0)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(words) != 1 || words[0].Word != "Frau" || words[0].Gender() != pkg.FEMININE {
+			t.Errorf("expected feminine Frau, got %v", words)
+		}
+	})
+}
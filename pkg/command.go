@@ -4,18 +4,50 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"example.com/gocab/pkg/rpc"
+	"google.golang.org/grpc"
 )
 
 type WordCommand struct {
 	Lang    string   `short:"l" long:"lang" required:"true" description:"foreign language"`
 	Word    string   `short:"w" long:"word" required:"true" description:"foreign word"`
-	Meaning string   `short:"m" long:"meaning" required:"true" description:"translation"`
+	Meaning []string `short:"m" long:"meaning" required:"true" description:"translation, repeated for each meaning, with an optional note in parentheses as in \"Husband (spouse)\""`
 	Tags    []string `short:"t" long:"tags" required:"true" description:"topics of the word"`
 
 	Pronunciation string `short:"p" long:"pronunciation" description:"how to pronounce the word"`
 	Example       string `short:"e" long:"example" description:"example sentence"`
+	Article       string `short:"a" long:"article" description:"article of a noun, also read from a word such as \"das Haus\""`
+	Phrase        bool   `long:"phrase" description:"keep the word as typed, without splitting a leading article, by tagging it as a phrase"`
+}
+
+func (c *WordCommand) word() *Word {
+	meanings := make([]Meaning, 0)
+	for _, meaning := range c.Meaning {
+		meanings = append(meanings, ParseMeanings(meaning)...)
+	}
+
+	tags := c.Tags
+	if c.Phrase {
+		tags = append(tags, TAG_PHRASE)
+	}
+
+	return &Word{
+		Lang:          c.Lang,
+		Word:          c.Word,
+		Article:       c.Article,
+		Meanings:      meanings,
+		Pronunciation: c.Pronunciation,
+		Example:       c.Example,
+		Tags:          tags,
+	}
 }
 
 type addCommand struct {
@@ -28,7 +60,7 @@ func CreateAddCommand(service Service) *addCommand {
 }
 
 func (c *addCommand) Execute(args []string) error {
-	_, err := c.service.AddWord(c.Lang, c.Word, c.Meaning, c.Pronunciation, c.Example, c.Tags)
+	_, err := c.service.AddWord(c.word())
 	return err
 }
 
@@ -42,17 +74,245 @@ func CreateUpdateCommand(service Service) *updateCommand {
 }
 
 func (c *updateCommand) Execute(args []string) error {
-	_, err := c.service.UpdateWord(c.Lang, c.Word, c.Meaning, c.Pronunciation, c.Example, c.Tags)
+	_, err := c.service.UpdateWord(c.word())
 	return err
 }
 
-type quizCommand struct {
+type fillCommand struct {
 	service Service
 	reader  io.Reader
 	writer  io.Writer
 
 	Lang string   `short:"l" long:"lang" required:"true" description:"foreign language"`
-	Tags []string `short:"t" long:"tags" description:"topics of the quiz"`
+	Tags []string `short:"t" long:"tags" description:"only drafts of these topics"`
+}
+
+func CreateFillCommand(service Service, reader io.Reader, writer io.Writer) *fillCommand {
+	return &fillCommand{service: service, reader: reader, writer: writer}
+}
+
+// Execute asks the meaning of every draft, the words given one being saved
+// right away so that filling can be stopped at the end of the input
+func (c *fillCommand) Execute(args []string) error {
+	words, err := c.service.ListWords(c.Lang, c.Tags, "", SORT_WORD, 0)
+	if err != nil {
+		return err
+	}
+
+	drafts := make([]*Word, 0)
+	for _, word := range words {
+		if word.Draft {
+			drafts = append(drafts, word)
+		}
+	}
+
+	if len(drafts) == 0 {
+		return ErrNoWordsFound
+	}
+
+	reader := bufio.NewReader(c.reader)
+	filled := 0
+
+	for i, draft := range drafts {
+		fmt.Fprintf(c.writer, "%s (%d/%d)\n", draft.WithArticle(), i+1, len(drafts))
+		if draft.Example != "" {
+			fmt.Fprintf(c.writer, "  %s\n", draft.Example)
+		}
+
+		meaning, ok, err := c.prompt(reader, "meaning, empty to skip: ")
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			break
+		}
+
+		if meaning == "" {
+			continue
+		}
+
+		word := *draft
+		word.Meanings = ParseMeanings(meaning)
+		word.Draft = false
+
+		pronunciation, ok, err := c.prompt(reader, fmt.Sprintf("pronunciation [%s]: ", draft.Pronunciation))
+		if err != nil {
+			return err
+		}
+
+		if pronunciation != "" {
+			word.Pronunciation = pronunciation
+		}
+
+		tags := ""
+		if ok {
+			tags, ok, err = c.prompt(reader, fmt.Sprintf("tags [%s]: ", strings.Join(draft.Tags, ", ")))
+			if err != nil {
+				return err
+			}
+		}
+
+		if tags != "" {
+			word.Tags = strings.FieldsFunc(tags, func(r rune) bool {
+				return r == ',' || r == ' '
+			})
+		}
+
+		if _, err := c.service.UpdateWord(&word); err != nil {
+			return err
+		}
+
+		filled++
+
+		if !ok {
+			break
+		}
+	}
+
+	_, err = fmt.Fprintf(c.writer, "filled %d word(s), %d draft(s) left\n", filled, len(drafts)-filled)
+
+	return err
+}
+
+// prompt reads a line, empty when the input ended without one
+func (c *fillCommand) prompt(reader *bufio.Reader, text string) (string, bool, error) {
+	if _, err := fmt.Fprint(c.writer, text); err != nil {
+		return "", false, err
+	}
+
+	line, err := reader.ReadString('\n')
+	if err == io.EOF {
+		return strings.TrimSpace(line), line != "", nil
+	}
+
+	return strings.TrimSpace(line), err == nil, err
+}
+
+type mineCommand struct {
+	service Service
+	reader  io.Reader
+	writer  io.Writer
+
+	Lang     string   `short:"l" long:"lang" required:"true" description:"foreign language"`
+	Filename string   `short:"f" long:"file" required:"true" description:"text or subtitles to find new words in"`
+	Format   string   `long:"format" choice:"srt" choice:"vtt" choice:"txt" description:"format of the file, guessed from its extension when omitted"`
+	Tags     []string `short:"t" long:"tags" description:"topics of the added words"`
+	Limit    int      `long:"limit" default:"30" description:"maximum number of words offered, the most frequent first"`
+	MinCount int      `long:"min-count" default:"1" description:"only words seen at least this many times"`
+}
+
+func CreateMineCommand(service Service, reader io.Reader, writer io.Writer) *mineCommand {
+	return &mineCommand{service: service, reader: reader, writer: writer, Limit: 30, MinCount: 1}
+}
+
+// Execute offers the most frequent words of the file that are not known
+// yet, adding the picked ones as drafts to fill in later
+func (c *mineCommand) Execute(args []string) error {
+	file, err := os.Open(c.Filename)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	format := c.Format
+	if format == "" {
+		format = GuessFormat(c.Filename)
+	}
+
+	sentences, err := ReadSentences(file, format)
+	if err != nil {
+		return err
+	}
+
+	mined, err := MineWords(sentences, func(word string) (bool, error) {
+		return c.service.HasWord(c.Lang, word)
+	})
+	if err != nil {
+		return err
+	}
+
+	words := make([]*MinedWord, 0)
+	for _, word := range mined {
+		if word.Count >= c.MinCount && (c.Limit <= 0 || len(words) < c.Limit) {
+			words = append(words, word)
+		}
+	}
+
+	if len(words) == 0 {
+		return ErrNoWordsFound
+	}
+
+	table := tabwriter.NewWriter(c.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "#\tWORD\tCOUNT\tEXAMPLE")
+
+	for i, word := range words {
+		fmt.Fprintf(table, "%d\t%s\t%d\t%s\n", i+1, word.Word, word.Count, truncate(word.Example, 60))
+	}
+
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprint(c.writer, "words to add, such as \"1 3 5-7\" or \"all\": "); err != nil {
+		return err
+	}
+
+	answer, err := bufio.NewReader(c.reader).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	selected, err := ParseSelection(answer, len(words))
+	if err != nil {
+		return err
+	}
+
+	for _, i := range selected {
+		word := &Word{
+			Lang:     c.Lang,
+			Word:     words[i-1].Word,
+			Meanings: make([]Meaning, 0),
+			Example:  words[i-1].Example,
+			Tags:     c.Tags,
+			Draft:    true,
+		}
+
+		if _, err := c.service.AddWord(word); err != nil {
+			return fmt.Errorf("%s: %w", word.Word, err)
+		}
+	}
+
+	_, err = fmt.Fprintf(c.writer, "added %d draft(s)\n", len(selected))
+
+	return err
+}
+
+// truncate shortens text to at most n letters
+func truncate(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+type quizCommand struct {
+	service Service
+	reader  io.Reader
+	writer  io.Writer
+
+	Lang    string   `short:"l" long:"lang" required:"true" description:"foreign language"`
+	Tags    []string `short:"t" long:"tags" description:"topics of the quiz"`
+	Mode    []string `long:"mode" choice:"sm2" choice:"fsrs" choice:"leitner" choice:"choice" description:"scheduling mode, defaults to the language's scheduler, or choice to pick answers from a list; can be repeated"`
+	Choices int      `long:"choices" default:"3" description:"number of wrong choices in choice mode"`
+
+	TypoRatio        float64 `long:"typo-ratio" default:"0.2" description:"share of an answer's letters that can be mistyped, 0 to only accept exact answers"`
+	MaxTypos         int     `long:"max-typos" default:"2" description:"maximum number of typos in an answer"`
+	NoTranspositions bool    `long:"no-transpositions" description:"count swapped letters as two typos"`
+	Strict           bool    `long:"strict" description:"require special letters, such as ü or ß, to be typed"`
+	Articles         string  `long:"articles" choice:"ignore" choice:"require" choice:"ask" default:"ignore" description:"whether nouns must be answered with their article, or their article is asked separately"`
 }
 
 func CreateQuizCommand(service Service, reader io.Reader, writer io.Writer) *quizCommand {
@@ -60,7 +320,7 @@ func CreateQuizCommand(service Service, reader io.Reader, writer io.Writer) *qui
 }
 
 func (c *quizCommand) Execute(args []string) error {
-	questions, err := c.service.CreateQuiz(c.Lang, c.Tags)
+	questions, err := c.service.CreateQuiz(c.Lang, c.Tags, c.options())
 	if err != nil {
 		return err
 	}
@@ -79,6 +339,28 @@ func (c *quizCommand) Execute(args []string) error {
 	return err
 }
 
+func (c *quizCommand) options() QuizOptions {
+	options := QuizOptions{
+		Matcher: &Matcher{
+			Ratio:          c.TypoRatio,
+			MaxTypos:       c.MaxTypos,
+			Transpositions: !c.NoTranspositions,
+		},
+		Strict:   c.Strict,
+		Articles: c.Articles,
+	}
+
+	for _, mode := range c.Mode {
+		if mode == "choice" {
+			options.Choices = c.Choices
+		} else {
+			options.Scheduler = mode
+		}
+	}
+
+	return options
+}
+
 func (c *quizCommand) runQuiz(questions []*Question) (*Summary, error) {
 	reader := bufio.NewReader(c.reader)
 	summary := &Summary{Total: len(questions)}
@@ -89,85 +371,569 @@ func (c *quizCommand) runQuiz(questions []*Question) (*Summary, error) {
 			return nil, err
 		}
 
+		start := time.Now()
+
 		answer, err := reader.ReadString('\n')
 		if err != nil {
 			return nil, err
 		}
 
-		// Set question's answer
+		// Set question's answer and how long it took
 		question.Answer = answer
+		question.Answered = time.Now()
+		question.Duration = question.Answered.Sub(start)
 
-		if question.IsCorrect() {
-			summary.Correct(question)
-		} else {
-			summary.Wrong(question)
-		}
+		summary.Record(question)
 	}
 
 	return summary, nil
 }
 
+type deleteCommand struct {
+	service Service
+	reader  io.Reader
+	writer  io.Writer
+
+	Lang string `short:"l" long:"lang" required:"true" description:"foreign language"`
+	Word string `short:"w" long:"word" description:"foreign word"`
+	Tag  string `short:"t" long:"tag" description:"delete every word of this topic"`
+	Yes  bool   `short:"y" long:"yes" description:"do not ask for confirmation"`
+}
+
+func CreateDeleteCommand(service Service, reader io.Reader, writer io.Writer) *deleteCommand {
+	return &deleteCommand{service: service, reader: reader, writer: writer}
+}
+
+func (c *deleteCommand) Execute(args []string) error {
+	words := make([]string, 0)
+
+	if c.Word != "" {
+		words = append(words, c.Word)
+	}
+
+	if c.Tag != "" {
+		found, err := c.service.ListWords(c.Lang, []string{c.Tag}, "", SORT_WORD, 0)
+		if err != nil {
+			return err
+		}
+
+		for _, word := range found {
+			words = append(words, word.Word)
+		}
+	}
+
+	if c.Word == "" && c.Tag == "" {
+		return ErrNoWordOrTag
+	}
+
+	if len(words) == 0 {
+		return ErrNoWordsFound
+	}
+
+	if !c.Yes {
+		confirmed, err := c.confirm(words)
+		if err != nil || !confirmed {
+			return err
+		}
+	}
+
+	for _, word := range words {
+		if err := c.service.DeleteWord(c.Lang, word); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(c.writer, "deleted %d word(s)\n", len(words))
+
+	return err
+}
+
+func (c *deleteCommand) confirm(words []string) (bool, error) {
+	_, err := fmt.Fprintf(c.writer, "delete %s? [y/N] ", strings.Join(words, ", "))
+	if err != nil {
+		return false, err
+	}
+
+	answer, err := bufio.NewReader(c.reader).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes", nil
+}
+
+type listCommand struct {
+	service Service
+	writer  io.Writer
+
+	Lang  string   `short:"l" long:"lang" required:"true" description:"foreign language"`
+	Tags  []string `short:"t" long:"tags" description:"topics of the words"`
+	Level string   `long:"level" choice:"hard" choice:"medium" choice:"easy" description:"only words of this level"`
+	Sort  string   `long:"sort" choice:"word" choice:"score" choice:"added" default:"word" description:"order of the words"`
+	Limit int      `long:"limit" description:"maximum number of words"`
+}
+
+func CreateListCommand(service Service, writer io.Writer) *listCommand {
+	return &listCommand{service: service, writer: writer}
+}
+
+func (c *listCommand) Execute(args []string) error {
+	words, err := c.service.ListWords(c.Lang, c.Tags, c.Level, c.Sort, c.Limit)
+	if err != nil {
+		return err
+	}
+
+	if len(words) == 0 {
+		return ErrNoWordsFound
+	}
+
+	table := tabwriter.NewWriter(c.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "WORD\tMEANING\tPRONUNCIATION\tTAGS\tLEVEL")
+
+	for _, word := range words {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n", word.WithArticle(), word.Meaning(), word.Pronunciation, strings.Join(word.Tags, ", "), word.Level())
+	}
+
+	return table.Flush()
+}
+
+type searchCommand struct {
+	service Service
+	writer  io.Writer
+
+	Lang string `short:"l" long:"lang" description:"foreign language, every language when omitted"`
+	Args struct {
+		Query []string `positional-arg-name:"query" required:"1"`
+	} `positional-args:"yes"`
+}
+
+func CreateSearchCommand(service Service, writer io.Writer) *searchCommand {
+	return &searchCommand{service: service, writer: writer}
+}
+
+func (c *searchCommand) Execute(args []string) error {
+	results, err := c.service.SearchWords(c.Lang, strings.Join(c.Args.Query, " "))
+	if err != nil {
+		return err
+	}
+
+	table := tabwriter.NewWriter(c.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "LANG\tWORD\tMEANING\tTAGS\tLEVEL\tMATCHED")
+
+	for _, result := range results {
+		word := result.Word
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\n", word.Lang, word.Word, word.Meaning(), strings.Join(word.Tags, ", "), word.Level(), strings.Join(result.Fields, ", "))
+	}
+
+	return table.Flush()
+}
+
+type configCommand struct {
+	service Service
+	writer  io.Writer
+
+	Lang      string  `short:"l" long:"lang" required:"true" description:"foreign language"`
+	Scheduler string  `short:"s" long:"scheduler" choice:"sm2" choice:"fsrs" choice:"leitner" description:"algorithm scheduling the reviews"`
+	Retention float64 `short:"r" long:"retention" description:"probability of remembering a word when it is reviewed (fsrs)"`
+	Boxes     int     `short:"b" long:"boxes" description:"number of boxes (leitner)"`
+	Intervals []int   `short:"i" long:"box-interval" description:"days between reviews of each box, repeated once per box (leitner)"`
+}
+
+func CreateConfigCommand(service Service, writer io.Writer) *configCommand {
+	return &configCommand{service: service, writer: writer}
+}
+
+func (c *configCommand) Execute(args []string) error {
+	settings, err := c.service.Settings(c.Lang)
+	if err != nil {
+		return err
+	}
+
+	if c.Scheduler != "" || c.Retention != 0 || c.Boxes != 0 || len(c.Intervals) != 0 {
+		if c.Scheduler != "" {
+			settings.Scheduler = c.Scheduler
+		}
+
+		if c.Retention != 0 {
+			settings.Retention = c.Retention
+		}
+
+		if c.Boxes != 0 {
+			settings.Boxes = c.Boxes
+			settings.BoxIntervals = nil
+		}
+
+		if len(c.Intervals) != 0 {
+			settings.BoxIntervals = c.Intervals
+			if c.Boxes == 0 {
+				settings.Boxes = len(c.Intervals)
+			}
+		}
+
+		if err := c.service.SaveSettings(settings); err != nil {
+			return err
+		}
+	}
+
+	scheduler := settings.Scheduler
+	if scheduler == "" {
+		scheduler = "default"
+	}
+
+	intervals := make([]string, 0)
+	for _, days := range settings.Intervals() {
+		intervals = append(intervals, fmt.Sprint(days))
+	}
+
+	_, err = fmt.Fprintf(
+		c.writer,
+		"scheduler: %s\nretention: %.2f\nboxes: %s days\n",
+		scheduler,
+		settings.Retention,
+		strings.Join(intervals, ", "),
+	)
+
+	return err
+}
+
 type importCommand struct {
 	writer  io.Writer
 	service Service
 
-	Language string `short:"l" long:"lang" required:"true" description:"foreign language"`
-	Filename string `short:"f" long:"file" required:"true" description:"csv file containing words to import"`
+	Language      string   `short:"l" long:"lang" required:"true" description:"foreign language"`
+	Filename      string   `short:"f" long:"file" required:"true" description:"file containing words to import, the header of csv and tsv files naming the columns"`
+	Format        string   `long:"format" choice:"csv" choice:"tsv" choice:"json" choice:"apkg" choice:"kindle" default:"csv" description:"format of the file, the progress on words it holds being restored for new words, kindle files being vocab.db databases whose words already registered are skipped"`
+	Delimiter     string   `short:"d" long:"delimiter" description:"field delimiter of csv files, such as \",\", \";\" or \"tab\", guessed from the header when omitted"`
+	LazyQuotes    bool     `long:"lazy-quotes" description:"accept quotes inside unquoted fields"`
+	ListSeparator string   `long:"list-separator" default:"|" description:"separator of the meanings and tags listed in a field"`
+	Fields        []string `long:"field" description:"note field of anki decks holding a column, such as \"meaning=Back\" or \"example=3\""`
 }
 
 func CreateImportCommand(service Service, writer io.Writer) *importCommand {
 	return &importCommand{
-		writer:  writer,
-		service: service,
+		writer:        writer,
+		service:       service,
+		ListSeparator: LIST_SEPARATOR,
 	}
 }
 
 func (c *importCommand) Execute(args []string) error {
-	file, err := os.OpenFile(c.Filename, os.O_RDONLY, os.ModeAppend)
+	file, err := os.Open(c.Filename)
 	if err != nil {
 		return err
 	}
 
-	content, err := io.ReadAll(file)
+	defer file.Close()
+
+	imported, failed, ignored, err := c.read(file)
 	if err != nil {
 		return err
 	}
 
-	words := make([]*Word, 0)
-	lines := strings.Split(string(content), "\n")
-	for i, line := range lines {
-		if i > 0 {
-			word, meaning, pronunciation, example, tags := c.parseLine(line)
-			if word == "" {
-				continue
+	// Words looked up while reading have no meaning to update known words with
+	skipped := 0
+	if c.Format == FORMAT_KINDLE {
+		unknown := make([]*ImportedWord, 0, len(imported))
+		for _, word := range imported {
+			exists, err := c.service.HasWord(word.Word.Lang, word.Word.Word)
+			if err != nil {
+				return err
 			}
 
-			words = append(words, &Word{
-				Lang:          c.Language,
-				Word:          word,
-				Meaning:       meaning,
-				Pronunciation: pronunciation,
-				Example:       example,
-				Tags:          strings.Split(tags, ","),
-			})
+			if exists {
+				skipped++
+			} else {
+				unknown = append(unknown, word)
+			}
 		}
+		imported = unknown
+	}
+
+	words := make([]*Word, 0, len(imported))
+	lines := make(map[string]int)
+
+	for _, word := range imported {
+		words = append(words, word.Word)
+		lines[withArticle(word.Word).Word] = word.Line
+	}
+
+	rejected := c.service.ImportWords(words)
+	for word, reason := range rejected {
+		failed = append(failed, &LineError{lines[word], fmt.Errorf("%s: %w", word, reason)})
+	}
+
+	sortLineErrors(failed)
+
+	fmt.Fprintf(c.writer, "imported %d word(s)\n", len(words)-len(rejected))
+
+	if len(ignored) != 0 {
+		fmt.Fprintf(c.writer, "ignored unknown column(s): %s\n", strings.Join(ignored, ", "))
+	}
+
+	if skipped != 0 {
+		fmt.Fprintf(c.writer, "skipped %d word(s) already registered\n", skipped)
 	}
 
-	failed := c.service.ImportWords(words)
 	if len(failed) != 0 {
-		c.writer.Write([]byte("could not import words:\n"))
-		for word, reason := range failed {
-			c.writer.Write([]byte(fmt.Sprintf("%s: %s\n", word, reason)))
+		fmt.Fprintf(c.writer, "could not import %d line(s):\n", len(failed))
+		for _, err := range failed {
+			fmt.Fprintln(c.writer, err)
 		}
 	}
 
 	return nil
 }
 
-func (c *importCommand) parseLine(line string) (string, string, string, string, string) {
-	parts := strings.Split(line, ";")
-	if len(parts) < 5 {
-		return "", "", "", "", ""
+// read returns the words of the file, the lines rejected and the unknown
+// columns ignored
+func (c *importCommand) read(file io.Reader) ([]*ImportedWord, []*LineError, []string, error) {
+	switch c.Format {
+	case FORMAT_JSON:
+		words, failed, err := NewJSONImporter(c.Language).Read(file)
+		return words, failed, nil, err
+	case FORMAT_KINDLE:
+		words, failed, err := NewKindleImporter(c.Language).Read(file)
+		return words, failed, nil, err
+	case FORMAT_APKG:
+		fields, err := ParseFieldMappings(c.Fields)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		importer := NewAnkiImporter(c.Language)
+		importer.Fields = fields
+
+		words, failed, err := importer.Read(file)
+		return words, failed, nil, err
+	}
+
+	importer := NewCSVImporter(c.Language)
+	importer.LazyQuotes = c.LazyQuotes
+	importer.ListSeparator = c.ListSeparator
+
+	delimiter, err := parseDelimiter(c.Delimiter)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	importer.Delimiter = delimiter
+
+	if c.Format == FORMAT_TSV {
+		importer.Delimiter = '\t'
+	}
+
+	words, failed, err := importer.Read(file)
+	return words, failed, importer.Ignored, err
+}
+
+// parseDelimiter reads a single character delimiter, or "tab"
+func parseDelimiter(delimiter string) (rune, error) {
+	switch delimiter {
+	case "":
+		return 0, nil
+	case "tab", "\\t":
+		return '\t', nil
+	}
+
+	runes := []rune(delimiter)
+	if len(runes) != 1 || runes[0] == '"' || runes[0] == '\n' || runes[0] == '\r' {
+		return 0, ErrInvalidDelimiter
 	}
-	return parts[0], parts[1], parts[2], parts[3], parts[4]
+
+	return runes[0], nil
+}
+
+type serveCommand struct {
+	service Service
+	tokens  TokenRepository
+	writer  io.Writer
+
+	Addr     string `long:"addr" default:":8080" description:"address to listen on"`
+	GrpcAddr string `long:"grpc-addr" description:"address to serve the grpc api on, not served when omitted"`
+	NoAuth   bool   `long:"no-auth" description:"serve the api without tokens, every request being made for the user, on trusted networks only"`
+}
+
+func CreateServeCommand(service Service, tokens TokenRepository, writer io.Writer) *serveCommand {
+	return &serveCommand{service: service, tokens: tokens, writer: writer, Addr: ":8080"}
+}
+
+func (c *serveCommand) Execute(args []string) error {
+	if _, err := fmt.Fprintf(c.writer, "listening on %s, quizzes can be taken in the browser at /\n", c.Addr); err != nil {
+		return err
+	}
+
+	tokens := c.tokens
+	if c.NoAuth {
+		tokens = nil
+	}
+
+	// Both servers call the same repository, which is not safe for
+	// concurrent use
+	mutex := &sync.Mutex{}
+	errs := make(chan error, 2)
+
+	if c.GrpcAddr != "" {
+		listener, err := net.Listen("tcp", c.GrpcAddr)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(c.writer, "serving grpc on %s\n", c.GrpcAddr); err != nil {
+			return err
+		}
+
+		server := grpc.NewServer()
+		rpc.RegisterGocabServer(server, NewGrpcServerWithMutex(c.service, tokens, mutex))
+
+		go func() {
+			errs <- server.Serve(listener)
+		}()
+	}
+
+	go func() {
+		errs <- http.ListenAndServe(c.Addr, NewServerWithMutex(c.service, tokens, mutex))
+	}()
+
+	return <-errs
+}
+
+// tokenCommand manages the API tokens of the user
+type tokenCommand struct {
+	Create *tokenCreateCommand `command:"create" description:"create an api token, shown only once"`
+	List   *tokenListCommand   `command:"list" description:"list api tokens"`
+	Revoke *tokenRevokeCommand `command:"revoke" description:"revoke an api token"`
+}
+
+func CreateTokenCommand(tokens TokenRepository, user string, writer io.Writer) *tokenCommand {
+	return &tokenCommand{
+		Create: &tokenCreateCommand{tokens: tokens, user: user, writer: writer, Scope: SCOPE_WRITE},
+		List:   &tokenListCommand{tokens: tokens, user: user, writer: writer},
+		Revoke: &tokenRevokeCommand{tokens: tokens, writer: writer},
+	}
+}
+
+type tokenCreateCommand struct {
+	tokens TokenRepository
+	user   string
+	writer io.Writer
+
+	Name  string `short:"n" long:"name" description:"what the token is for"`
+	Scope string `short:"s" long:"scope" choice:"read" choice:"write" default:"write" description:"whether the token can change words and save answers"`
+}
+
+func (c *tokenCreateCommand) Execute(args []string) error {
+	secret, token, err := NewToken(c.user, c.Name, c.Scope)
+	if err != nil {
+		return err
+	}
+
+	if token, err = c.tokens.AddToken(token); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(c.writer, "created %s token %d for %s, it cannot be shown again:\n%s\n", token.Scope, token.ID, token.User, secret)
+
+	return err
+}
+
+type tokenListCommand struct {
+	tokens TokenRepository
+	user   string
+	writer io.Writer
+
+	All bool `short:"a" long:"all" description:"tokens of every user"`
+}
+
+func (c *tokenListCommand) Execute(args []string) error {
+	user := c.user
+	if c.All {
+		user = ""
+	}
+
+	tokens, err := c.tokens.ListTokens(user)
+	if err != nil {
+		return err
+	}
+
+	if len(tokens) == 0 {
+		return ErrTokenNotFound
+	}
+
+	table := tabwriter.NewWriter(c.writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ID\tUSER\tSCOPE\tNAME\tCREATED")
+
+	for _, token := range tokens {
+		fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%s\n", token.ID, token.User, token.Scope, token.Name, token.Created.Format("2006-01-02"))
+	}
+
+	return table.Flush()
+}
+
+type tokenRevokeCommand struct {
+	tokens TokenRepository
+	writer io.Writer
+
+	Args struct {
+		IDs []int64 `positional-arg-name:"id" required:"1"`
+	} `positional-args:"yes"`
+}
+
+func (c *tokenRevokeCommand) Execute(args []string) error {
+	for _, id := range c.Args.IDs {
+		if err := c.tokens.DeleteToken(id); err != nil {
+			return fmt.Errorf("token %d: %w", id, err)
+		}
+	}
+
+	_, err := fmt.Fprintf(c.writer, "revoked %d token(s)\n", len(c.Args.IDs))
+
+	return err
+}
+
+type exportCommand struct {
+	service Service
+	writer  io.Writer
+
+	Lang     string   `short:"l" long:"lang" required:"true" description:"foreign language"`
+	Tags     []string `short:"t" long:"tags" description:"only words of these topics"`
+	Filename string   `short:"f" long:"file" description:"file to write, the standard output when omitted"`
+	Format   string   `long:"format" choice:"csv" choice:"tsv" choice:"json" choice:"apkg" default:"csv" description:"format of the file, apkg files being decks anki opens"`
+}
+
+func CreateExportCommand(service Service, writer io.Writer) *exportCommand {
+	return &exportCommand{service: service, writer: writer, Format: FORMAT_CSV}
+}
+
+func (c *exportCommand) Execute(args []string) error {
+	words, err := c.service.ListWords(c.Lang, c.Tags, "", SORT_WORD, 0)
+	if err != nil {
+		return err
+	}
+
+	if len(words) == 0 {
+		return ErrNoWordsFound
+	}
+
+	if c.Filename == "" {
+		return ExportWords(c.writer, words, c.Format)
+	}
+
+	file, err := os.Create(c.Filename)
+	if err != nil {
+		return err
+	}
+
+	if err := ExportWords(file, words, c.Format); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(c.writer, "exported %d word(s) to %s\n", len(words), c.Filename)
+
+	return err
 }
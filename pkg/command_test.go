@@ -2,6 +2,11 @@ package pkg_test
 
 import (
 	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strings"
 	"testing"
 
 	"example.com/gocab/pkg"
@@ -18,6 +23,44 @@ func TestAddCommand(t *testing.T) {
 		}
 	})
 
+	t.Run("meanings", func(t *testing.T) {
+		repository := pkg.NewInMemoryRepository()
+		cmd := pkg.CreateAddCommand(pkg.NewService(repository))
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "-w", "Mann", "-m", "Man", "-m", "Husband (spouse)", "-t", "noun"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		words, _ := repository.FindWords("german", nil)
+		if len(words) != 1 || words[0].Meaning() != "Man; Husband (spouse)" {
+			t.Errorf("expected both meanings, got %v", words)
+		}
+	})
+
+	t.Run("phrase", func(t *testing.T) {
+		repository := pkg.NewInMemoryRepository()
+		cmd := pkg.CreateAddCommand(pkg.NewService(repository))
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "-w", "die Zeit", "-m", "the time", "-t", "expression", "--phrase"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		words, _ := repository.FindWords("german", nil)
+		if len(words) != 1 || words[0].Word != "die Zeit" || words[0].Article != "" {
+			t.Errorf("expected die Zeit to be kept as typed, got %v", words)
+		}
+	})
+
 	t.Run("required", func(t *testing.T) {
 		cmd := pkg.CreateAddCommand(pkg.NewService(pkg.NewInMemoryRepository()))
 
@@ -62,7 +105,7 @@ func TestUpdateCommand(t *testing.T) {
 		repository := pkg.NewInMemoryRepository()
 		cmd := pkg.CreateUpdateCommand(pkg.NewService(repository))
 
-		repository.AddWord("german", "Hallo", "Hello", "", "", []string{})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Hallo", Meanings: pkg.ParseMeanings("Hello")})
 
 		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "-w", "Hallo", "-m", "Hello", "-e", "Hallo, wie gehts", "-t", "greetings"})
 		if err != nil {
@@ -109,6 +152,122 @@ func TestUpdateCommand(t *testing.T) {
 	})
 }
 
+func TestFillCommand(t *testing.T) {
+	t.Run("fill", func(t *testing.T) {
+		reader := bytes.NewBuffer([]byte("\nvermin\nˈʊnɡəˌfiːɐ̯\n\nto arrest\n\nverb, kafka\n"))
+		writer := bytes.NewBuffer(nil)
+
+		repository := newSqliteRepository(t)
+		cmd := pkg.CreateFillCommand(pkg.NewService(repository), reader, writer)
+
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Träumen", Example: "aus unruhigen Träumen", Tags: []string{"die-verwandlung"}, Draft: true})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "verhaften", Tags: []string{"der-process"}, Draft: true})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Ungeziefer", Tags: []string{"die-verwandlung"}, Draft: true})
+
+		if _, err := flags.ParseArgs(cmd, []string{"-l", "german"}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if !strings.Contains(writer.String(), "Träumen (1/3)\n  aus unruhigen Träumen\n") {
+			t.Errorf("expected drafts to be shown with their example, got %q", writer.String())
+		}
+
+		if !strings.HasSuffix(writer.String(), "filled 2 word(s), 1 draft(s) left\n") {
+			t.Errorf("expected 2 filled words, got %q", writer.String())
+		}
+
+		words, _ := repository.ListWords("german", nil, "", pkg.SORT_WORD, 0)
+		filled := make(map[string]*pkg.Word)
+		for _, word := range words {
+			filled[word.Word] = word
+		}
+
+		if word := filled["Träumen"]; !word.Draft || len(word.Meanings) != 0 {
+			t.Errorf("expected skipped word to stay a draft, got %v", word)
+		}
+
+		if word := filled["verhaften"]; word.Draft || word.Meaning() != "to arrest" || strings.Join(word.Tags, ",") != "verb,kafka" {
+			t.Errorf("expected verhaften to be filled, got %v", word)
+		}
+
+		if word := filled["Ungeziefer"]; word.Draft || word.Pronunciation != "ˈʊnɡəˌfiːɐ̯" || strings.Join(word.Tags, ",") != "die-verwandlung" {
+			t.Errorf("expected Ungeziefer to keep its tags, got %v", word)
+		}
+	})
+
+	t.Run("end of input", func(t *testing.T) {
+		reader := bytes.NewBuffer([]byte("to arrest"))
+		writer := bytes.NewBuffer(nil)
+
+		repository := pkg.NewInMemoryRepository()
+		cmd := pkg.CreateFillCommand(pkg.NewService(repository), reader, writer)
+
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "verhaften", Draft: true})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Ungeziefer", Draft: true})
+
+		if _, err := flags.ParseArgs(cmd, []string{"-l", "german"}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if !strings.HasSuffix(writer.String(), "filled 1 word(s), 1 draft(s) left\n") {
+			t.Errorf("expected filling to stop at the end of input, got %q", writer.String())
+		}
+	})
+
+	t.Run("no drafts", func(t *testing.T) {
+		repository := pkg.NewInMemoryRepository()
+		cmd := pkg.CreateFillCommand(pkg.NewService(repository), bytes.NewBuffer(nil), bytes.NewBuffer(nil))
+
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House")})
+
+		if _, err := flags.ParseArgs(cmd, []string{"-l", "german"}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != pkg.ErrNoWordsFound {
+			t.Errorf("expected error %v, got %v", pkg.ErrNoWordsFound, err)
+		}
+	})
+}
+
+// answerReader answers the last question written by a quiz, as questions
+// are asked in either direction
+type answerReader struct {
+	writer  *bytes.Buffer
+	answers map[string]string
+	pending []byte
+}
+
+func (r *answerReader) Read(p []byte) (int, error) {
+	if len(r.pending) == 0 {
+		lines := strings.Split(strings.TrimSpace(r.writer.String()), "\n")
+		question := lines[len(lines)-1]
+
+		for text, answer := range r.answers {
+			if strings.Contains(question, text) {
+				r.pending = []byte(answer + "\n")
+			}
+		}
+	}
+
+	if len(r.pending) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
 func TestQuizCommand(t *testing.T) {
 	t.Run("no words", func(t *testing.T) {
 		reader := bytes.NewBuffer(nil)
@@ -127,13 +286,13 @@ func TestQuizCommand(t *testing.T) {
 	})
 
 	t.Run("quiz", func(t *testing.T) {
-		reader := bytes.NewBuffer([]byte("Hello\n"))
 		writer := bytes.NewBuffer([]byte(""))
+		reader := &answerReader{writer: writer, answers: map[string]string{"What does Hallo mean": "Hello", "How do you say \"Hello\"": "Hallo"}}
 
 		repository := pkg.NewInMemoryRepository()
 		cmd := pkg.CreateQuizCommand(pkg.NewService(repository), reader, writer)
 
-		repository.AddWord("german", "Hallo", "Hello", "", "", []string{})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Hallo", Meanings: pkg.ParseMeanings("Hello")})
 
 		_, err := flags.ParseArgs(cmd, []string{"-l", "german"})
 		if err != nil {
@@ -154,3 +313,477 @@ func TestQuizCommand(t *testing.T) {
 		}
 	})
 }
+
+func TestQuizCommandChoice(t *testing.T) {
+	reader := bytes.NewBuffer([]byte("1\n"))
+	writer := bytes.NewBuffer(nil)
+
+	repository := pkg.NewInMemoryRepository()
+	cmd := pkg.CreateQuizCommand(pkg.NewService(repository), reader, writer)
+
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Hallo", Meanings: pkg.ParseMeanings("Hello")})
+
+	_, err := flags.ParseArgs(cmd, []string{"-l", "german", "--mode", "choice", "--mode", "leitner"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := cmd.Execute([]string{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !strings.HasPrefix(writer.String(), "[Box 1] What does Hallo mean?\n  1) Hello\n") {
+		t.Errorf("expected choices to be listed, got %q", writer.String())
+	}
+
+	words, _ := repository.FindWords("german", nil)
+	if words[0].Box != 2 {
+		t.Errorf("expected word to move to box %d, got %d", 2, words[0].Box)
+	}
+}
+
+func TestQuizCommandLeitner(t *testing.T) {
+	reader := bytes.NewBuffer([]byte("Hello\n"))
+	writer := bytes.NewBuffer(nil)
+
+	repository := pkg.NewInMemoryRepository()
+	service := pkg.NewService(repository)
+	cmd := pkg.CreateQuizCommand(service, reader, writer)
+
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Hallo", Meanings: pkg.ParseMeanings("Hello")})
+	service.SaveSettings(&pkg.Settings{Lang: "german", Retention: 0.9, Boxes: 3})
+
+	_, err := flags.ParseArgs(cmd, []string{"-l", "german", "--mode", "leitner"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := cmd.Execute([]string{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !strings.HasPrefix(writer.String(), "[Box 1]") {
+		t.Errorf("expected question to show the box, got %q", writer.String())
+	}
+
+	words, _ := repository.FindWords("german", nil)
+	if words[0].Box == 0 {
+		t.Error("expected word to be placed in a box")
+	}
+
+	if words[0].Interval != 1 && words[0].Interval != 2 {
+		t.Errorf("expected interval of box %d, got %d", words[0].Box, words[0].Interval)
+	}
+}
+
+func TestConfigCommand(t *testing.T) {
+	t.Run("config", func(t *testing.T) {
+		writer := bytes.NewBuffer(nil)
+		service := pkg.NewService(pkg.NewInMemoryRepository())
+		cmd := pkg.CreateConfigCommand(service, writer)
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "-s", "fsrs", "-r", "0.85"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		settings, _ := service.Settings("german")
+		if settings.Scheduler != pkg.FSRS || settings.Retention != 0.85 {
+			t.Errorf("expected fsrs with retention 0.85, got %v", settings)
+		}
+
+		if writer.String() != "scheduler: fsrs\nretention: 0.85\nboxes: 1, 2, 4, 8, 16 days\n" {
+			t.Errorf("unexpected output %q", writer.String())
+		}
+	})
+
+	t.Run("unknown scheduler", func(t *testing.T) {
+		cmd := pkg.CreateConfigCommand(pkg.NewService(pkg.NewInMemoryRepository()), bytes.NewBuffer(nil))
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "-s", "anki"})
+		if err == nil {
+			t.Fatal("should error, unknown scheduler")
+		}
+	})
+
+	t.Run("leitner boxes", func(t *testing.T) {
+		writer := bytes.NewBuffer(nil)
+		service := pkg.NewService(pkg.NewInMemoryRepository())
+		cmd := pkg.CreateConfigCommand(service, writer)
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "-s", "leitner", "-i", "1", "-i", "3", "-i", "7"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		settings, _ := service.Settings("german")
+		if settings.Boxes != 3 {
+			t.Errorf("expected %d boxes, got %d", 3, settings.Boxes)
+		}
+
+		if !strings.HasSuffix(writer.String(), "boxes: 1, 3, 7 days\n") {
+			t.Errorf("unexpected output %q", writer.String())
+		}
+	})
+
+	t.Run("mismatched boxes", func(t *testing.T) {
+		cmd := pkg.CreateConfigCommand(pkg.NewService(pkg.NewInMemoryRepository()), bytes.NewBuffer(nil))
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "-b", "4", "-i", "1", "-i", "3"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != pkg.ErrInvalidBoxes {
+			t.Errorf("expected error %v, got %v", pkg.ErrInvalidBoxes, err)
+		}
+	})
+}
+
+func TestListCommand(t *testing.T) {
+	t.Run("list", func(t *testing.T) {
+		writer := bytes.NewBuffer(nil)
+		repository := pkg.NewInMemoryRepository()
+		cmd := pkg.CreateListCommand(pkg.NewService(repository), writer)
+
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man; Husband"), Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Er", Meanings: pkg.ParseMeanings("He"), Tags: []string{"pronoun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Pronunciation: "haus", Tags: []string{"noun", "building"}})
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "-t", "noun"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		expected := "WORD  MEANING       PRONUNCIATION  TAGS            LEVEL\n" +
+			"Haus  House         haus           noun, building  Hard\n" +
+			"Mann  Man; Husband                 noun            Hard\n"
+
+		if writer.String() != expected {
+			t.Errorf("expected table\n%s\ngot\n%s", expected, writer.String())
+		}
+	})
+
+	t.Run("level", func(t *testing.T) {
+		writer := bytes.NewBuffer(nil)
+		repository := pkg.NewInMemoryRepository()
+		cmd := pkg.CreateListCommand(pkg.NewService(repository), writer)
+
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man"), Tags: []string{"noun"}})
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "--level", "easy"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != pkg.ErrNoWordsFound {
+			t.Errorf("expected error %v, got %v", pkg.ErrNoWordsFound, err)
+		}
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		cmd := pkg.CreateListCommand(pkg.NewService(pkg.NewInMemoryRepository()), bytes.NewBuffer(nil))
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "--sort", "meaning"})
+		if err == nil {
+			t.Fatal("should error, unknown sort order")
+		}
+	})
+}
+
+func TestDeleteCommand(t *testing.T) {
+	t.Run("delete", func(t *testing.T) {
+		reader := bytes.NewBuffer([]byte("y\n"))
+		writer := bytes.NewBuffer(nil)
+
+		repository := pkg.NewInMemoryRepository()
+		cmd := pkg.CreateDeleteCommand(pkg.NewService(repository), reader, writer)
+
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man"), Tags: []string{"noun"}})
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "-w", "Haus"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if exists, _ := repository.HasWord("german", "Haus"); exists {
+			t.Error("should have deleted word \"Haus\"")
+		}
+
+		if exists, _ := repository.HasWord("german", "Mann"); !exists {
+			t.Error("should have kept word \"Mann\"")
+		}
+	})
+
+	t.Run("article", func(t *testing.T) {
+		reader := bytes.NewBuffer([]byte("y\n"))
+		writer := bytes.NewBuffer(nil)
+
+		repository := pkg.NewInMemoryRepository()
+		cmd := pkg.CreateDeleteCommand(pkg.NewService(repository), reader, writer)
+
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Article: "das", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "-w", "das Haus"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if exists, _ := repository.HasWord("german", "Haus"); exists {
+			t.Error("should have deleted word \"das Haus\"")
+		}
+	})
+
+	t.Run("tag", func(t *testing.T) {
+		reader := bytes.NewBuffer([]byte("yes\n"))
+		writer := bytes.NewBuffer(nil)
+
+		repository := pkg.NewInMemoryRepository()
+		cmd := pkg.CreateDeleteCommand(pkg.NewService(repository), reader, writer)
+
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man"), Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Er", Meanings: pkg.ParseMeanings("He"), Tags: []string{"pronoun"}})
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "-t", "noun"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if !strings.HasPrefix(writer.String(), "delete Haus, Mann? [y/N] ") {
+			t.Errorf("expected confirmation prompt, got %q", writer.String())
+		}
+
+		words, _ := repository.ListWords("german", nil, "", pkg.SORT_WORD, 0)
+		if len(words) != 1 || words[0].Word != "Er" {
+			t.Errorf("expected only Er to be left, got %v", words)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		reader := bytes.NewBuffer([]byte("\n"))
+		writer := bytes.NewBuffer(nil)
+
+		repository := pkg.NewInMemoryRepository()
+		cmd := pkg.CreateDeleteCommand(pkg.NewService(repository), reader, writer)
+
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "-w", "Haus"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if exists, _ := repository.HasWord("german", "Haus"); !exists {
+			t.Error("should have kept word \"Haus\"")
+		}
+	})
+
+	t.Run("not registered", func(t *testing.T) {
+		cmd := pkg.CreateDeleteCommand(pkg.NewService(pkg.NewInMemoryRepository()), bytes.NewBuffer(nil), bytes.NewBuffer(nil))
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "-w", "Haus", "-y"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != pkg.ErrWordNotRegistered {
+			t.Errorf("expected error %v, got %v", pkg.ErrWordNotRegistered, err)
+		}
+	})
+
+	t.Run("required", func(t *testing.T) {
+		cmd := pkg.CreateDeleteCommand(pkg.NewService(pkg.NewInMemoryRepository()), bytes.NewBuffer(nil), bytes.NewBuffer(nil))
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != pkg.ErrNoWordOrTag {
+			t.Errorf("expected error %v, got %v", pkg.ErrNoWordOrTag, err)
+		}
+	})
+}
+
+func TestSearchCommand(t *testing.T) {
+	t.Run("search", func(t *testing.T) {
+		writer := bytes.NewBuffer(nil)
+		repository := pkg.NewInMemoryRepository()
+		cmd := pkg.CreateSearchCommand(pkg.NewService(repository), writer)
+
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Example: "Mein Haus ist weit weg", Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man"), Example: "Mein Mann ist stark", Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "spanish", Word: "Casa", Meanings: pkg.ParseMeanings("House"), Example: "Mi casa es tu casa", Tags: []string{"noun"}})
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german", "haus"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		expected := "LANG    WORD  MEANING  TAGS  LEVEL  MATCHED\n" +
+			"german  Haus  House    noun  Hard   word, example\n"
+
+		if writer.String() != expected {
+			t.Errorf("expected table\n%s\ngot\n%s", expected, writer.String())
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		cmd := pkg.CreateSearchCommand(pkg.NewService(pkg.NewInMemoryRepository()), bytes.NewBuffer(nil))
+
+		_, err := flags.ParseArgs(cmd, []string{"haus"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != pkg.ErrNoWordsFound {
+			t.Errorf("expected error %v, got %v", pkg.ErrNoWordsFound, err)
+		}
+	})
+
+	t.Run("required", func(t *testing.T) {
+		cmd := pkg.CreateSearchCommand(pkg.NewService(pkg.NewInMemoryRepository()), bytes.NewBuffer(nil))
+
+		_, err := flags.ParseArgs(cmd, []string{"-l", "german"})
+		if err == nil {
+			t.Fatal("should error, no query provided")
+		}
+	})
+}
+
+func TestImportCommand(t *testing.T) {
+	filename := path.Join(t.TempDir(), "words.csv")
+	content := "word;meaning;pronunciation;example;tags\nMann;Man|Husband (spouse);;Mein Mann ist stark;noun\n"
+
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	repository := pkg.NewInMemoryRepository()
+	writer := bytes.NewBuffer(nil)
+	cmd := pkg.CreateImportCommand(pkg.NewService(repository), writer)
+
+	if _, err := flags.ParseArgs(cmd, []string{"-l", "german", "-f", filename}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := cmd.Execute([]string{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	words, _ := repository.FindWords("german", nil)
+	if len(words) != 1 {
+		t.Fatalf("expected 1 word, got %d", len(words))
+	}
+
+	if len(words[0].Meanings) != 2 || words[0].Meanings[1] != (pkg.Meaning{Text: "Husband", Note: "spouse"}) {
+		t.Errorf("expected meanings to be split on %q, got %v", pkg.LIST_SEPARATOR, words[0].Meanings)
+	}
+}
+
+func TestImportCommandReport(t *testing.T) {
+	filename := path.Join(t.TempDir(), "words.tsv")
+	content := "word\tmeaning\tcolour\nHaus\tHouse\tred\n\tMan\tblue\nFrau\tWoman\tgreen\n"
+
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	repository := pkg.NewInMemoryRepository()
+	writer := bytes.NewBuffer(nil)
+	cmd := pkg.CreateImportCommand(pkg.NewService(repository), writer)
+
+	if _, err := flags.ParseArgs(cmd, []string{"-l", "german", "-f", filename, "-d", "tab"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := cmd.Execute([]string{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := "imported 2 word(s)\nignored unknown column(s): colour\ncould not import 1 line(s):\nline 3: missing word\n"
+	if writer.String() != expected {
+		t.Errorf("expected %q, got %q", expected, writer.String())
+	}
+}
+
+func TestTokenCommand(t *testing.T) {
+	repository := pkg.NewInMemoryRepository()
+	writer := bytes.NewBuffer(nil)
+
+	// Subcommands are executed as they are parsed
+	if _, err := flags.ParseArgs(pkg.CreateTokenCommand(repository, "alice", writer), []string{"create", "-s", "read", "-n", "dashboard"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(writer.String()), "\n")
+	if len(lines) != 2 || lines[0] != "created read token 1 for alice, it cannot be shown again:" {
+		t.Fatalf("expected the token to be shown, got %q", writer.String())
+	}
+
+	token, err := repository.FindToken(pkg.HashToken(lines[1]))
+	if err != nil {
+		t.Fatalf("expected the token to be stored hashed, got %v", err)
+	}
+
+	if token.User != "alice" || token.Scope != pkg.SCOPE_READ || token.Name != "dashboard" {
+		t.Errorf("expected read token of alice for dashboard, got %v", token)
+	}
+
+	flags.ParseArgs(pkg.CreateTokenCommand(repository, "bob", bytes.NewBuffer(nil)), []string{"create"})
+
+	writer.Reset()
+	if _, err := flags.ParseArgs(pkg.CreateTokenCommand(repository, "bob", writer), []string{"list"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !strings.Contains(writer.String(), "bob") || strings.Contains(writer.String(), "alice") {
+		t.Errorf("expected the tokens of bob only, got\n%s", writer.String())
+	}
+
+	if _, err := flags.ParseArgs(pkg.CreateTokenCommand(repository, "bob", bytes.NewBuffer(nil)), []string{"revoke", "1"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := repository.FindToken(token.Hash); err != pkg.ErrTokenNotFound {
+		t.Errorf("expected error %v, got %v", pkg.ErrTokenNotFound, err)
+	}
+
+	if _, err := flags.ParseArgs(pkg.CreateTokenCommand(repository, "bob", bytes.NewBuffer(nil)), []string{"revoke", "1"}); !errors.Is(err, pkg.ErrTokenNotFound) {
+		t.Errorf("expected error %v, got %v", pkg.ErrTokenNotFound, err)
+	}
+}
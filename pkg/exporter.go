@@ -0,0 +1,112 @@
+package pkg
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+// Formats of exported and imported files
+const FORMAT_CSV = "csv"
+const FORMAT_TSV = "tsv"
+const FORMAT_JSON = "json"
+const FORMAT_APKG = "apkg"
+
+// Columns holding the scheduling state, exported so a backup restores the
+// progress made on every word
+const COLUMN_SCORE = "score"
+const COLUMN_ADDED = "added"
+const COLUMN_EASE = "ease"
+const COLUMN_INTERVAL = "interval"
+const COLUMN_REPETITIONS = "repetitions"
+const COLUMN_DUE = "due"
+const COLUMN_REVIEWED = "reviewed"
+const COLUMN_STABILITY = "stability"
+const COLUMN_DIFFICULTY = "difficulty"
+const COLUMN_BOX = "box"
+const COLUMN_DRAFT = "draft"
+
+var exportColumns = []string{
+	COLUMN_WORD, COLUMN_ARTICLE, COLUMN_MEANING, COLUMN_PRONUNCIATION, COLUMN_EXAMPLE, COLUMN_TAGS,
+	COLUMN_SCORE, COLUMN_ADDED, COLUMN_EASE, COLUMN_INTERVAL, COLUMN_REPETITIONS, COLUMN_DUE,
+	COLUMN_REVIEWED, COLUMN_STABILITY, COLUMN_DIFFICULTY, COLUMN_BOX, COLUMN_DRAFT,
+}
+
+// ExportWords writes words in a format the import command reads back
+func ExportWords(writer io.Writer, words []*Word, format string) error {
+	switch format {
+	case FORMAT_CSV:
+		return exportDelimited(writer, words, ',')
+	case FORMAT_TSV:
+		return exportDelimited(writer, words, '\t')
+	case FORMAT_JSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(words)
+	case FORMAT_APKG:
+		return exportAnki(writer, words)
+	}
+	return ErrUnknownFormat
+}
+
+func exportDelimited(writer io.Writer, words []*Word, delimiter rune) error {
+	records := csv.NewWriter(writer)
+	records.Comma = delimiter
+
+	if err := records.Write(exportColumns); err != nil {
+		return err
+	}
+
+	for _, word := range words {
+		meanings := make([]string, len(word.Meanings))
+		for i, meaning := range word.Meanings {
+			meanings[i] = meaning.String()
+		}
+
+		record := []string{
+			word.Word,
+			word.Article,
+			strings.Join(meanings, LIST_SEPARATOR),
+			word.Pronunciation,
+			word.Example,
+			strings.Join(word.Tags, LIST_SEPARATOR),
+			formatFloat(word.Score),
+			formatTime(word.Added),
+			formatFloat(word.Ease),
+			strconv.Itoa(word.Interval),
+			strconv.Itoa(word.Repetitions),
+			formatTime(word.Due),
+			formatTime(word.Reviewed),
+			formatFloat(word.Stability),
+			formatFloat(word.Difficulty),
+			strconv.Itoa(word.Box),
+			strconv.FormatBool(word.Draft),
+		}
+
+		if err := records.Write(record); err != nil {
+			return err
+		}
+	}
+
+	records.Flush()
+
+	return records.Error()
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// formatTime writes times as RFC 3339, the zero time as an empty field
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,128 @@
+package pkg_test
+
+import (
+	"bytes"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"example.com/gocab/pkg"
+	"github.com/jessevdk/go-flags"
+)
+
+func TestExportCommand(t *testing.T) {
+	for _, format := range []string{pkg.FORMAT_CSV, pkg.FORMAT_TSV, pkg.FORMAT_JSON} {
+		t.Run(format, func(t *testing.T) {
+			repository := newSqliteRepository(t)
+			service := pkg.NewService(repository)
+
+			service.AddWord(&pkg.Word{Lang: "german", Word: "das Haus", Meanings: pkg.ParseMeanings("House; Home (my place)"), Example: "Das Haus, \"mein\" Haus", Tags: []string{"noun", "building"}})
+			service.AddWord(&pkg.Word{Lang: "german", Word: "gehen", Meanings: pkg.ParseMeanings("to go, to walk"), Pronunciation: "ˈɡeːən", Tags: []string{"verb"}})
+
+			words, _ := repository.FindWords("german", []string{"noun"})
+			summary := &pkg.Summary{Total: 1}
+			summary.Correct(&pkg.Question{Type: pkg.FOREIGN_TO_ENGLISH, Word: words[0], Answer: "House"})
+			service.SaveResult(summary)
+
+			filename := path.Join(t.TempDir(), "words."+format)
+			writer := bytes.NewBuffer(nil)
+			cmd := pkg.CreateExportCommand(service, writer)
+
+			if _, err := flags.ParseArgs(cmd, []string{"-l", "german", "-f", filename, "--format", format}); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if err := cmd.Execute([]string{}); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if !strings.Contains(writer.String(), "exported 2 word(s)") {
+				t.Errorf("expected exported words to be counted, got %q", writer.String())
+			}
+
+			imported := newSqliteRepository(t)
+			importCmd := pkg.CreateImportCommand(pkg.NewService(imported), bytes.NewBuffer(nil))
+
+			if _, err := flags.ParseArgs(importCmd, []string{"-l", "german", "-f", filename, "--format", format}); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if err := importCmd.Execute([]string{}); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			expected, _ := repository.ListWords("german", nil, "", pkg.SORT_WORD, 0)
+			found, _ := imported.ListWords("german", nil, "", pkg.SORT_WORD, 0)
+
+			if len(found) != len(expected) {
+				t.Fatalf("expected %d words, got %d", len(expected), len(found))
+			}
+
+			for i := range expected {
+				e, f := expected[i], found[i]
+
+				if f.WithArticle() != e.WithArticle() || f.Meaning() != e.Meaning() || f.Example != e.Example || f.Pronunciation != e.Pronunciation {
+					t.Errorf("expected %v, got %v", e, f)
+				}
+
+				if strings.Join(f.Tags, ",") != strings.Join(e.Tags, ",") {
+					t.Errorf("expected tags %v, got %v", e.Tags, f.Tags)
+				}
+
+				if f.Score != e.Score || f.Ease != e.Ease || f.Repetitions != e.Repetitions || !f.Due.Equal(e.Due) || !f.Added.Equal(e.Added) {
+					t.Errorf("expected progress of %s to be restored, got %v", e.Word, f)
+				}
+			}
+		})
+	}
+
+	t.Run("stdout", func(t *testing.T) {
+		repository := pkg.NewInMemoryRepository()
+		service := pkg.NewService(repository)
+
+		service.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+		service.AddWord(&pkg.Word{Lang: "german", Word: "Er", Meanings: pkg.ParseMeanings("He"), Tags: []string{"pronoun"}})
+
+		writer := bytes.NewBuffer(nil)
+		cmd := pkg.CreateExportCommand(service, writer)
+
+		if _, err := flags.ParseArgs(cmd, []string{"-l", "german", "-t", "noun"}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if err := cmd.Execute([]string{}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(writer.String()), "\n")
+		if len(lines) != 2 || !strings.HasPrefix(lines[0], "word,article,meaning") || !strings.HasPrefix(lines[1], "Haus,,House,,,noun,0,") {
+			t.Errorf("expected header and Haus, got %q", writer.String())
+		}
+	})
+}
+
+func TestJSONImporter(t *testing.T) {
+	content := `[
+  {"word": "Haus", "meanings": [{"text": "House"}], "tags": ["noun"], "due": "2024-01-02T00:00:00Z"},
+  {"word": "", "meanings": []},
+  {"word": "Mann", "score": "high"}
+]`
+
+	words, failed, err := pkg.NewJSONImporter("german").Read(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(words) != 1 || words[0].Word.Lang != "german" || words[0].Line != 2 {
+		t.Fatalf("expected Haus on line 2, got %v", words)
+	}
+
+	if !words[0].Word.Due.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected due date to be read, got %v", words[0].Word.Due)
+	}
+
+	if len(failed) != 2 || failed[0].Line != 3 || failed[1].Line != 4 {
+		t.Errorf("expected lines 3 and 4 to be rejected, got %v", failed)
+	}
+}
@@ -0,0 +1,460 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"example.com/gocab/pkg/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// questionTypeValues are the question types of the gRPC contract
+var questionTypeValues = map[int]rpc.QuestionType{
+	FOREIGN_TO_ENGLISH: rpc.QuestionType_QUESTION_TYPE_FOREIGN_TO_ENGLISH,
+	ENGLISH_TO_FOREIGN: rpc.QuestionType_QUESTION_TYPE_ENGLISH_TO_FOREIGN,
+	MULTIPLE_CHOICE:    rpc.QuestionType_QUESTION_TYPE_MULTIPLE_CHOICE,
+	CLOZE:              rpc.QuestionType_QUESTION_TYPE_CLOZE,
+	ARTICLE:            rpc.QuestionType_QUESTION_TYPE_ARTICLE,
+}
+
+var resultValues = map[Result]rpc.Result{
+	WRONG:         rpc.Result_RESULT_WRONG,
+	WRONG_ARTICLE: rpc.Result_RESULT_WRONG_ARTICLE,
+	ALMOST:        rpc.Result_RESULT_ALMOST,
+	NORMALIZED:    rpc.Result_RESULT_NORMALIZED,
+	EXACT:         rpc.Result_RESULT_EXACT,
+}
+
+// statusCodes are the gRPC codes of the HTTP statuses errors are given
+var statusCodes = map[int]codes.Code{
+	http.StatusNotFound:         codes.NotFound,
+	http.StatusMethodNotAllowed: codes.Unimplemented,
+	http.StatusUnauthorized:     codes.Unauthenticated,
+	http.StatusForbidden:        codes.PermissionDenied,
+	http.StatusConflict:         codes.AlreadyExists,
+	http.StatusBadRequest:       codes.InvalidArgument,
+}
+
+// GrpcServer exposes the service through the gRPC contract of
+// rpc/gocab.proto, as Server does through the JSON API
+type GrpcServer struct {
+	rpc.UnimplementedGocabServer
+
+	service Service
+
+	// tokens authenticate the calls, every call being made for the
+	// service's user when nil
+	tokens TokenRepository
+
+	// mutex serializes calls to the service, repositories not being safe
+	// for concurrent use. It is shared with the JSON API of the same service.
+	mutex *sync.Mutex
+}
+
+func NewGrpcServer(service Service) *GrpcServer {
+	return NewGrpcServerWithTokens(service, nil)
+}
+
+// NewGrpcServerWithTokens creates a server requiring a token in the
+// authorization metadata of calls, which are then made for the user of the
+// token
+func NewGrpcServerWithTokens(service Service, tokens TokenRepository) *GrpcServer {
+	return NewGrpcServerWithMutex(service, tokens, &sync.Mutex{})
+}
+
+// NewGrpcServerWithMutex creates a server holding the given mutex while
+// calling the service, for other servers of the service to share it
+func NewGrpcServerWithMutex(service Service, tokens TokenRepository, mutex *sync.Mutex) *GrpcServer {
+	return &GrpcServer{service: service, tokens: tokens, mutex: mutex}
+}
+
+// serviceFor authenticates a call, returning the service of its user
+func (s *GrpcServer) serviceFor(ctx context.Context) (Service, error) {
+	authorization := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			authorization = values[0]
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	service, _, err := authenticate(s.service, s.tokens, authorization)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return service, nil
+}
+
+// call runs f with the service of the user making the call
+func (s *GrpcServer) call(ctx context.Context, f func(service Service) error) error {
+	service, err := s.serviceFor(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := f(service); err != nil {
+		return grpcError(err)
+	}
+
+	return nil
+}
+
+func (s *GrpcServer) AddWord(ctx context.Context, request *rpc.AddWordRequest) (*rpc.Word, error) {
+	var added *Word
+	err := s.call(ctx, func(service Service) (err error) {
+		added, err = service.AddWord(fromWordMessage(request.Word))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newWordMessage(added), nil
+}
+
+func (s *GrpcServer) UpdateWord(ctx context.Context, request *rpc.UpdateWordRequest) (*rpc.Word, error) {
+	var updated *Word
+	err := s.call(ctx, func(service Service) (err error) {
+		updated, err = service.UpdateWord(fromWordMessage(request.Word))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newWordMessage(updated), nil
+}
+
+func (s *GrpcServer) HasWord(ctx context.Context, request *rpc.HasWordRequest) (*rpc.HasWordResponse, error) {
+	response := &rpc.HasWordResponse{}
+	err := s.call(ctx, func(service Service) (err error) {
+		response.Exists, err = service.HasWord(request.Lang, request.Word)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *GrpcServer) DeleteWord(ctx context.Context, request *rpc.DeleteWordRequest) (*rpc.DeleteWordResponse, error) {
+	err := s.call(ctx, func(service Service) error {
+		return service.DeleteWord(request.Lang, request.Word)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpc.DeleteWordResponse{}, nil
+}
+
+func (s *GrpcServer) ListWords(ctx context.Context, request *rpc.ListWordsRequest) (*rpc.ListWordsResponse, error) {
+	response := &rpc.ListWordsResponse{}
+	err := s.call(ctx, func(service Service) error {
+		words, err := service.ListWords(request.Lang, request.Tags, request.Level, request.Sort, int(request.Limit))
+		for _, word := range words {
+			response.Words = append(response.Words, newWordMessage(word))
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *GrpcServer) SearchWords(ctx context.Context, request *rpc.SearchWordsRequest) (*rpc.SearchWordsResponse, error) {
+	response := &rpc.SearchWordsResponse{}
+	err := s.call(ctx, func(service Service) error {
+		results, err := service.SearchWords(request.Lang, request.Query)
+		for _, result := range results {
+			response.Results = append(response.Results, &rpc.SearchResult{Word: newWordMessage(result.Word), Fields: result.Fields})
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *GrpcServer) ImportWords(ctx context.Context, request *rpc.ImportWordsRequest) (*rpc.ImportWordsResponse, error) {
+	response := &rpc.ImportWordsResponse{Rejected: make(map[string]string)}
+	err := s.call(ctx, func(service Service) error {
+		words := make([]*Word, len(request.Words))
+		for i, word := range request.Words {
+			words[i] = fromWordMessage(word)
+		}
+
+		for word, err := range service.ImportWords(words) {
+			if errors.Is(err, ErrReadOnly) {
+				return err
+			}
+			response.Rejected[word] = err.Error()
+		}
+		response.Imported = int32(len(words) - len(response.Rejected))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *GrpcServer) GetSettings(ctx context.Context, request *rpc.GetSettingsRequest) (*rpc.Settings, error) {
+	var settings *Settings
+	err := s.call(ctx, func(service Service) (err error) {
+		settings, err = service.Settings(request.Lang)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newSettingsMessage(settings), nil
+}
+
+func (s *GrpcServer) SaveSettings(ctx context.Context, request *rpc.Settings) (*rpc.Settings, error) {
+	settings := &Settings{
+		Lang:      request.Lang,
+		Scheduler: request.Scheduler,
+		Retention: request.Retention,
+		Boxes:     int(request.Boxes),
+	}
+	for _, days := range request.BoxIntervals {
+		settings.BoxIntervals = append(settings.BoxIntervals, int(days))
+	}
+
+	err := s.call(ctx, func(service Service) error {
+		return service.SaveSettings(settings)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newSettingsMessage(settings), nil
+}
+
+// TakeQuiz asks the questions of a quiz one at a time, grading and saving
+// every answer right away so that the progress made on quizzes left
+// unfinished is kept
+func (s *GrpcServer) TakeQuiz(stream rpc.Gocab_TakeQuizServer) error {
+	request, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	options := request.GetStart()
+	if options == nil {
+		return grpcError(fmt.Errorf("%w: a quiz starts with its options", ErrInvalidRequest))
+	}
+
+	var questions []*Question
+	err = s.call(stream.Context(), func(service Service) (err error) {
+		questions, err = service.CreateQuiz(options.Lang, options.Tags, QuizOptions{
+			Scheduler: options.Scheduler,
+			Strict:    options.Strict,
+			Articles:  options.Articles,
+			Choices:   int(options.Choices),
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	summary := &Summary{Total: len(questions)}
+
+	for i, question := range questions {
+		err := stream.Send(&rpc.TakeQuizResponse{Response: &rpc.TakeQuizResponse_Question{
+			Question: &rpc.Question{
+				Id:      int32(i),
+				Total:   int32(len(questions)),
+				Type:    questionTypeValues[question.Type],
+				Level:   question.Level(),
+				Text:    strings.TrimSpace(question.Text()),
+				Choices: question.Choices,
+			},
+		}})
+		if err != nil {
+			return err
+		}
+
+		asked := time.Now()
+
+		request, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		answer := request.GetAnswer()
+		if answer == nil {
+			return grpcError(fmt.Errorf("%w: expected an answer", ErrInvalidRequest))
+		}
+
+		question.Answer = answer.Text
+		question.Answered = time.Now()
+		question.Duration = question.Answered.Sub(asked)
+
+		err = s.call(stream.Context(), func(service Service) error {
+			return service.SaveResult(&Summary{Total: 1, Questions: []*Question{question}})
+		})
+		if err != nil {
+			return err
+		}
+
+		result := summary.Record(question)
+
+		err = stream.Send(&rpc.TakeQuizResponse{Response: &rpc.TakeQuizResponse_Grade{
+			Grade: &rpc.Grade{
+				Result:        resultValues[result],
+				Correct:       question.IsCorrect(),
+				Expected:      question.ExpectedAnswer(),
+				Pronunciation: question.Word.Pronunciation,
+			},
+		}})
+		if err != nil {
+			return err
+		}
+	}
+
+	response := newSummaryResponse(summary)
+
+	return stream.Send(&rpc.TakeQuizResponse{Response: &rpc.TakeQuizResponse_Summary{
+		Summary: &rpc.Summary{
+			Total:         int32(response.Total),
+			Correct:       int32(response.Correct),
+			NearMisses:    int32(response.NearMisses),
+			WrongArticles: int32(response.WrongArticles),
+			Mistakes:      int32(response.Mistakes),
+			Text:          response.Text,
+		},
+	}})
+}
+
+func newWordMessage(word *Word) *rpc.Word {
+	message := &rpc.Word{
+		Lang:          word.Lang,
+		Word:          word.Word,
+		Article:       word.Article,
+		Draft:         word.Draft,
+		Pronunciation: word.Pronunciation,
+		Example:       word.Example,
+		Tags:          word.Tags,
+		Added:         newTimestamp(word.Added),
+		Score:         word.Score,
+		Ease:          word.Ease,
+		Interval:      int32(word.Interval),
+		Repetitions:   int32(word.Repetitions),
+		Due:           newTimestamp(word.Due),
+		Reviewed:      newTimestamp(word.Reviewed),
+		Stability:     word.Stability,
+		Difficulty:    word.Difficulty,
+		Box:           int32(word.Box),
+	}
+
+	for _, meaning := range word.Meanings {
+		message.Meanings = append(message.Meanings, &rpc.Meaning{Text: meaning.Text, Note: meaning.Note})
+	}
+
+	return message
+}
+
+func fromWordMessage(message *rpc.Word) *Word {
+	if message == nil {
+		return &Word{}
+	}
+
+	word := &Word{
+		Lang:          message.Lang,
+		Word:          message.Word,
+		Article:       message.Article,
+		Draft:         message.Draft,
+		Pronunciation: message.Pronunciation,
+		Example:       message.Example,
+		Tags:          message.Tags,
+		Added:         fromTimestamp(message.Added),
+		Score:         message.Score,
+		Ease:          message.Ease,
+		Interval:      int(message.Interval),
+		Repetitions:   int(message.Repetitions),
+		Due:           fromTimestamp(message.Due),
+		Reviewed:      fromTimestamp(message.Reviewed),
+		Stability:     message.Stability,
+		Difficulty:    message.Difficulty,
+		Box:           int(message.Box),
+	}
+
+	for _, meaning := range message.Meanings {
+		word.Meanings = append(word.Meanings, Meaning{Text: meaning.Text, Note: meaning.Note})
+	}
+
+	return word
+}
+
+func newSettingsMessage(settings *Settings) *rpc.Settings {
+	message := &rpc.Settings{
+		Lang:      settings.Lang,
+		Scheduler: settings.Scheduler,
+		Retention: settings.Retention,
+		Boxes:     int32(settings.Boxes),
+	}
+
+	for _, days := range settings.BoxIntervals {
+		message.BoxIntervals = append(message.BoxIntervals, int32(days))
+	}
+
+	return message
+}
+
+// newTimestamp converts a time to a timestamp, the zero time being left
+// unset
+func newTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func fromTimestamp(timestamp *timestamppb.Timestamp) time.Time {
+	if timestamp == nil {
+		return time.Time{}
+	}
+	return timestamp.AsTime()
+}
+
+// grpcError converts an error to the status of its gRPC code
+func grpcError(err error) error {
+	code, ok := statusCodes[errorStatus(err)]
+	if !ok {
+		code = codes.Internal
+	}
+
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,314 @@
+package pkg_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"example.com/gocab/pkg"
+	"example.com/gocab/pkg/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newGrpcClient serves the server on an in-process listener
+func newGrpcClient(t *testing.T, server *pkg.GrpcServer) rpc.GocabClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	s := grpc.NewServer()
+	rpc.RegisterGocabServer(s, server)
+
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	t.Cleanup(func() { conn.Close() })
+
+	return rpc.NewGocabClient(conn)
+}
+
+func TestGrpcServerWords(t *testing.T) {
+	repository := pkg.NewInMemoryRepository()
+	client := newGrpcClient(t, pkg.NewGrpcServer(pkg.NewService(repository)))
+	ctx := context.Background()
+
+	t.Run("add", func(t *testing.T) {
+		word, err := client.AddWord(ctx, &rpc.AddWordRequest{Word: &rpc.Word{
+			Lang: "german", Word: "das Haus", Meanings: []*rpc.Meaning{{Text: "House"}}, Tags: []string{"noun"},
+		}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if word.Word != "Haus" || word.Article != "das" || word.Added == nil {
+			t.Errorf("expected das Haus to be added, got %v", word)
+		}
+
+		_, err = client.AddWord(ctx, &rpc.AddWordRequest{Word: &rpc.Word{Lang: "german", Word: "Haus"}})
+		if status.Code(err) != codes.AlreadyExists {
+			t.Errorf("expected code %v, got %v", codes.AlreadyExists, err)
+		}
+	})
+
+	t.Run("import", func(t *testing.T) {
+		response, err := client.ImportWords(ctx, &rpc.ImportWordsRequest{Words: []*rpc.Word{
+			{Lang: "german", Word: "Mann", Meanings: []*rpc.Meaning{{Text: "Man"}, {Text: "Husband", Note: "spouse"}}, Tags: []string{"noun"}},
+			{Lang: "german", Word: "gehen", Meanings: []*rpc.Meaning{{Text: "to go"}}, Tags: []string{"verb"}},
+		}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if response.Imported != 2 || len(response.Rejected) != 0 {
+			t.Errorf("expected 2 imported words, got %v", response)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		response, err := client.ListWords(ctx, &rpc.ListWordsRequest{Lang: "german", Tags: []string{"noun"}, Sort: pkg.SORT_WORD})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(response.Words) != 2 || response.Words[0].Word != "Haus" || response.Words[1].Word != "Mann" {
+			t.Fatalf("expected Haus and Mann, got %v", response.Words)
+		}
+
+		if meanings := response.Words[1].Meanings; len(meanings) != 2 || meanings[1].Note != "spouse" {
+			t.Errorf("expected the meanings of Mann, got %v", meanings)
+		}
+
+		_, err = client.ListWords(ctx, &rpc.ListWordsRequest{Lang: "german", Sort: "length"})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("expected code %v, got %v", codes.InvalidArgument, err)
+		}
+	})
+
+	t.Run("search", func(t *testing.T) {
+		response, err := client.SearchWords(ctx, &rpc.SearchWordsRequest{Query: "husband"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(response.Results) != 1 || response.Results[0].Word.Word != "Mann" {
+			t.Errorf("expected Mann, got %v", response.Results)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		word, err := client.UpdateWord(ctx, &rpc.UpdateWordRequest{Word: &rpc.Word{
+			Lang: "german", Word: "das Haus", Meanings: []*rpc.Meaning{{Text: "House"}, {Text: "Home"}}, Tags: []string{"noun"},
+		}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(word.Meanings) != 2 {
+			t.Errorf("expected Haus to be updated, got %v", word)
+		}
+	})
+
+	t.Run("update phrase", func(t *testing.T) {
+		phrase := &rpc.Word{Lang: "german", Word: "die Hard", Meanings: []*rpc.Meaning{{Text: "die hard"}}, Tags: []string{pkg.TAG_PHRASE}}
+		if _, err := client.AddWord(ctx, &rpc.AddWordRequest{Word: phrase}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		phrase.Meanings = []*rpc.Meaning{{Text: "stubborn"}}
+
+		word, err := client.UpdateWord(ctx, &rpc.UpdateWordRequest{Word: phrase})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if word.Word != "die Hard" || word.Article != "" || word.Meanings[0].Text != "stubborn" {
+			t.Errorf("expected die Hard to be updated as typed, got %v", word)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if _, err := client.DeleteWord(ctx, &rpc.DeleteWordRequest{Lang: "german", Word: "gehen"}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if response, _ := client.HasWord(ctx, &rpc.HasWordRequest{Lang: "german", Word: "gehen"}); response.Exists {
+			t.Error("should have deleted word \"gehen\"")
+		}
+
+		_, err := client.DeleteWord(ctx, &rpc.DeleteWordRequest{Lang: "german", Word: "gehen"})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected code %v, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("settings", func(t *testing.T) {
+		_, err := client.SaveSettings(ctx, &rpc.Settings{Lang: "german", Scheduler: pkg.LEITNER, Retention: pkg.DEFAULT_RETENTION, Boxes: 3, BoxIntervals: []int32{1, 2, 4}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		settings, err := client.GetSettings(ctx, &rpc.GetSettingsRequest{Lang: "german"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if settings.Scheduler != pkg.LEITNER || settings.Boxes != 3 || len(settings.BoxIntervals) != 3 {
+			t.Errorf("expected leitner boxes, got %v", settings)
+		}
+	})
+}
+
+func TestGrpcServerTakeQuiz(t *testing.T) {
+	repository := pkg.NewInMemoryRepository()
+	service := pkg.NewService(repository)
+	client := newGrpcClient(t, pkg.NewGrpcServer(service))
+
+	service.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+	service.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man"), Tags: []string{"noun"}})
+
+	stream, err := client.TakeQuiz(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	start := &rpc.TakeQuizRequest{Request: &rpc.TakeQuizRequest_Start{Start: &rpc.QuizOptions{Lang: "german", Choices: 1}}}
+	if err := stream.Send(start); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Answers are the position of the picked choice in choice mode
+	answers := map[string]string{"House": "Haus", "Man": "Mann"}
+	grades := make([]*rpc.Grade, 0)
+
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if summary := response.GetSummary(); summary != nil {
+			if summary.Total != 2 || summary.Correct != 1 || summary.Mistakes != 1 {
+				t.Errorf("expected 1 right and 1 wrong answer, got %v", summary)
+			}
+			break
+		}
+
+		question := response.GetQuestion()
+		if question == nil || question.Total != 2 || question.Type != rpc.QuestionType_QUESTION_TYPE_MULTIPLE_CHOICE {
+			t.Fatalf("expected a multiple choice question, got %v", response)
+		}
+
+		// The first question is answered right, the second one wrong
+		answer := "wrong"
+		if question.Id == 0 {
+			for i, choice := range question.Choices {
+				if strings.Contains(question.Text, answers[choice]+" mean") {
+					answer = strconv.Itoa(i + 1)
+				}
+			}
+		}
+
+		if err := stream.Send(&rpc.TakeQuizRequest{Request: &rpc.TakeQuizRequest_Answer{Answer: &rpc.Answer{Text: answer}}}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		response, err = stream.Recv()
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		grade := response.GetGrade()
+		if grade == nil {
+			t.Fatalf("expected a grade, got %v", response)
+		}
+
+		grades = append(grades, grade)
+	}
+
+	if len(grades) != 2 || !grades[0].Correct || grades[1].Correct || grades[1].Result != rpc.Result_RESULT_WRONG {
+		t.Errorf("expected a right then a wrong answer, got %v", grades)
+	}
+
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("expected the stream to end after the summary, got %v", err)
+	}
+
+	if reviews, _ := repository.FindReviews("german", ""); len(reviews) != 2 {
+		t.Errorf("expected %d reviews, got %d", 2, len(reviews))
+	}
+}
+
+func TestGrpcServerTokens(t *testing.T) {
+	repository := pkg.NewInMemoryRepository()
+	service := pkg.NewService(repository)
+	client := newGrpcClient(t, pkg.NewGrpcServerWithTokens(service, repository))
+
+	service.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+
+	secret, token, _ := pkg.NewToken("alice", "dashboard", pkg.SCOPE_READ)
+	repository.AddToken(token)
+
+	_, err := client.ListWords(context.Background(), &rpc.ListWordsRequest{Lang: "german"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected code %v, got %v", codes.Unauthenticated, err)
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+secret)
+
+	if response, err := client.ListWords(ctx, &rpc.ListWordsRequest{Lang: "german"}); err != nil || len(response.Words) != 1 {
+		t.Errorf("expected Haus to be listed, got %v and %v", response, err)
+	}
+
+	_, err = client.DeleteWord(ctx, &rpc.DeleteWordRequest{Lang: "german", Word: "Haus"})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected code %v, got %v", codes.PermissionDenied, err)
+	}
+}
+
+func TestGrpcServerMutex(t *testing.T) {
+	service := pkg.NewService(pkg.NewInMemoryRepository())
+	mutex := &sync.Mutex{}
+	client := newGrpcClient(t, pkg.NewGrpcServerWithMutex(service, nil, mutex))
+
+	// Holding the mutex, as the JSON API does while handling a request,
+	// keeps calls waiting
+	mutex.Lock()
+
+	done := make(chan error)
+	go func() {
+		_, err := client.HasWord(context.Background(), &rpc.HasWordRequest{Lang: "german", Word: "Haus"})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("expected the call to wait for the mutex, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	mutex.Unlock()
+
+	if err := <-done; err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,364 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMissingWordColumn = errors.New("missing word column")
+	ErrDuplicateColumn   = errors.New("duplicate column")
+	ErrUnknownColumn     = errors.New("unknown column")
+	ErrMissingWord       = errors.New("missing word")
+	ErrInvalidDelimiter  = errors.New("delimiter must be a single character")
+)
+
+// Columns of an imported file, named in its header
+const COLUMN_WORD = "word"
+const COLUMN_ARTICLE = "article"
+const COLUMN_MEANING = "meaning"
+const COLUMN_PRONUNCIATION = "pronunciation"
+const COLUMN_EXAMPLE = "example"
+const COLUMN_TAGS = "tags"
+
+// LIST_SEPARATOR separates the meanings or tags listed in a column
+const LIST_SEPARATOR = "|"
+
+// byteOrderMark is written at the start of files by some spreadsheets
+var byteOrderMark = []byte("\ufeff")
+
+// LineError tells why a line of an imported file was rejected
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// ImportedWord is a word read from a file along with the line it was on
+type ImportedWord struct {
+	Line int
+	Word *Word
+}
+
+// CSVImporter reads words from delimited files whose header names the
+// columns, in any order, only the word column being required. Columns it
+// does not know are ignored.
+type CSVImporter struct {
+	Lang string
+
+	// Delimiter separates the fields, guessed from the header when zero
+	Delimiter rune
+
+	// LazyQuotes accepts quotes appearing in unquoted fields
+	LazyQuotes bool
+
+	// ListSeparator separates the meanings and tags listed in a field,
+	// commas also separating tags
+	ListSeparator string
+
+	// Ignored lists the unknown columns of the last file read
+	Ignored []string
+}
+
+func NewCSVImporter(lang string) *CSVImporter {
+	return &CSVImporter{Lang: lang, ListSeparator: LIST_SEPARATOR}
+}
+
+// Read returns the words of every valid line and an error for every line
+// rejected, failing only when the file itself cannot be read
+func (i *CSVImporter) Read(reader io.Reader) ([]*ImportedWord, []*LineError, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content = bytes.TrimPrefix(content, byteOrderMark)
+
+	delimiter := i.Delimiter
+	if delimiter == 0 {
+		delimiter = guessDelimiter(content)
+	}
+
+	records := csv.NewReader(bytes.NewReader(content))
+	records.Comma = delimiter
+	records.LazyQuotes = i.LazyQuotes
+	records.FieldsPerRecord = -1
+
+	header, err := records.Read()
+	if err == io.EOF {
+		return nil, nil, ErrMissingWordColumn
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns, ignored, err := mapColumns(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	i.Ignored = ignored
+
+	words := make([]*ImportedWord, 0)
+	failed := make([]*LineError, 0)
+
+	for {
+		record, err := records.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			failed = append(failed, &LineError{parseErr.StartLine, parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		line, _ := records.FieldPos(0)
+
+		if len(record) != len(header) {
+			failed = append(failed, &LineError{line, fmt.Errorf("expected %d fields, got %d", len(header), len(record))})
+			continue
+		}
+
+		word, err := i.parseRecord(record, columns)
+		if err != nil {
+			failed = append(failed, &LineError{line, err})
+			continue
+		}
+
+		if word.Word == "" {
+			failed = append(failed, &LineError{line, ErrMissingWord})
+			continue
+		}
+
+		words = append(words, &ImportedWord{line, word})
+	}
+
+	return words, failed, nil
+}
+
+func (i *CSVImporter) parseRecord(record []string, columns map[string]int) (*Word, error) {
+	field := func(column string) string {
+		if index, ok := columns[column]; ok {
+			return strings.TrimSpace(record[index])
+		}
+		return ""
+	}
+
+	word := &Word{
+		Lang:          i.Lang,
+		Word:          field(COLUMN_WORD),
+		Article:       field(COLUMN_ARTICLE),
+		Meanings:      make([]Meaning, 0),
+		Pronunciation: field(COLUMN_PRONUNCIATION),
+		Example:       field(COLUMN_EXAMPLE),
+		Tags:          make([]string, 0),
+	}
+
+	for _, text := range strings.Split(field(COLUMN_MEANING), i.ListSeparator) {
+		if meaning := ParseMeaning(text); meaning.Text != "" {
+			word.Meanings = append(word.Meanings, meaning)
+		}
+	}
+
+	tags := strings.FieldsFunc(field(COLUMN_TAGS), func(r rune) bool {
+		return r == ',' || strings.ContainsRune(i.ListSeparator, r)
+	})
+
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			word.Tags = append(word.Tags, tag)
+		}
+	}
+
+	// Scheduling state of exported words, every column being optional
+	var err error
+	floats := map[string]*float64{
+		COLUMN_SCORE:      &word.Score,
+		COLUMN_EASE:       &word.Ease,
+		COLUMN_STABILITY:  &word.Stability,
+		COLUMN_DIFFICULTY: &word.Difficulty,
+	}
+	ints := map[string]*int{
+		COLUMN_INTERVAL:    &word.Interval,
+		COLUMN_REPETITIONS: &word.Repetitions,
+		COLUMN_BOX:         &word.Box,
+	}
+	times := map[string]*time.Time{
+		COLUMN_ADDED:    &word.Added,
+		COLUMN_DUE:      &word.Due,
+		COLUMN_REVIEWED: &word.Reviewed,
+	}
+
+	for column, value := range floats {
+		if text := field(column); text != "" && err == nil {
+			*value, err = strconv.ParseFloat(text, 64)
+		}
+	}
+
+	for column, value := range ints {
+		if text := field(column); text != "" && err == nil {
+			*value, err = strconv.Atoi(text)
+		}
+	}
+
+	for column, value := range times {
+		if text := field(column); text != "" && err == nil {
+			*value, err = time.Parse(time.RFC3339, text)
+		}
+	}
+
+	if text := field(COLUMN_DRAFT); text != "" && err == nil {
+		word.Draft, err = strconv.ParseBool(text)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return word, nil
+}
+
+// mapColumns finds the index of every known column named in the header,
+// returning the names of the unknown ones
+func mapColumns(header []string) (map[string]int, []string, error) {
+	columns := make(map[string]int)
+	ignored := make([]string, 0)
+
+	for index, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		known := false
+		for _, column := range exportColumns {
+			known = known || column == name
+		}
+
+		if !known {
+			if name != "" {
+				ignored = append(ignored, name)
+			}
+			continue
+		}
+
+		if _, ok := columns[name]; ok {
+			return nil, nil, fmt.Errorf("%w: %s", ErrDuplicateColumn, name)
+		}
+
+		columns[name] = index
+	}
+
+	if _, ok := columns[COLUMN_WORD]; !ok {
+		return nil, nil, ErrMissingWordColumn
+	}
+
+	return columns, ignored, nil
+}
+
+// guessDelimiter picks the most frequent of tab, semicolon and comma in
+// the header line, commas winning ties
+func guessDelimiter(content []byte) rune {
+	header := content
+	if end := bytes.IndexByte(content, '\n'); end >= 0 {
+		header = content[:end]
+	}
+
+	delimiter := ','
+	count := bytes.Count(header, []byte{','})
+
+	for _, candidate := range []rune{';', '\t'} {
+		if n := bytes.Count(header, []byte(string(candidate))); n > count {
+			delimiter = candidate
+			count = n
+		}
+	}
+
+	return delimiter
+}
+
+// sortLineErrors orders rejected lines as they appear in the file
+func sortLineErrors(failed []*LineError) {
+	sort.SliceStable(failed, func(i, j int) bool {
+		return failed[i].Line < failed[j].Line
+	})
+}
+
+// JSONImporter reads words from a list of JSON objects, as exported
+type JSONImporter struct {
+	Lang string
+}
+
+func NewJSONImporter(lang string) *JSONImporter {
+	return &JSONImporter{Lang: lang}
+}
+
+// Read returns the words of every valid object and an error for every
+// object rejected, along with the line it starts on
+func (i *JSONImporter) Read(reader io.Reader) ([]*ImportedWord, []*LineError, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content = bytes.TrimPrefix(content, byteOrderMark)
+	decoder := json.NewDecoder(bytes.NewReader(content))
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, nil, fmt.Errorf("%w: expected a list of words", ErrUnknownFormat)
+	}
+
+	words := make([]*ImportedWord, 0)
+	failed := make([]*LineError, 0)
+
+	for decoder.More() {
+		line := lineAt(content, decoder.InputOffset())
+
+		var word Word
+		err := decoder.Decode(&word)
+
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			failed = append(failed, &LineError{line, err})
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if strings.TrimSpace(word.Word) == "" {
+			failed = append(failed, &LineError{line, ErrMissingWord})
+			continue
+		}
+
+		word.Lang = i.Lang
+		words = append(words, &ImportedWord{line, &word})
+	}
+
+	return words, failed, nil
+}
+
+// lineAt returns the line of the first value found after offset
+func lineAt(content []byte, offset int64) int {
+	for offset < int64(len(content)) && strings.ContainsRune(" \t\r\n,", rune(content[offset])) {
+		offset++
+	}
+	return bytes.Count(content[:offset], []byte{'\n'}) + 1
+}
@@ -0,0 +1,146 @@
+package pkg_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"example.com/gocab/pkg"
+)
+
+func TestCSVImporter(t *testing.T) {
+	read := func(t *testing.T, importer *pkg.CSVImporter, content string) ([]*pkg.ImportedWord, []*pkg.LineError) {
+		t.Helper()
+
+		words, failed, err := importer.Read(strings.NewReader(content))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		return words, failed
+	}
+
+	t.Run("header", func(t *testing.T) {
+		content := "\ufefftags,Meaning,word\r\n\"noun,building\",\"House|Home (my place)\",Haus\r\nnoun,Man,Mann\r\n"
+
+		words, failed := read(t, pkg.NewCSVImporter("german"), content)
+		if len(failed) != 0 {
+			t.Fatalf("expected no rejected line, got %v", failed)
+		}
+
+		if len(words) != 2 {
+			t.Fatalf("expected 2 words, got %d", len(words))
+		}
+
+		haus := words[0].Word
+		if haus.Word != "Haus" || haus.Lang != "german" || words[0].Line != 2 {
+			t.Errorf("expected Haus on line 2, got %q on line %d", haus.Word, words[0].Line)
+		}
+
+		if haus.Meaning() != "House; Home (my place)" {
+			t.Errorf("expected House; Home (my place), got %s", haus.Meaning())
+		}
+
+		if strings.Join(haus.Tags, " ") != "noun building" {
+			t.Errorf("expected tags noun and building, got %v", haus.Tags)
+		}
+
+		if words[1].Line != 3 || words[1].Word.Pronunciation != "" {
+			t.Errorf("expected Mann on line 3 without pronunciation, got line %d", words[1].Line)
+		}
+	})
+
+	t.Run("delimiters", func(t *testing.T) {
+		cases := map[string]string{
+			"semicolon": "word;meaning;example\nHaus;House;\"Das Haus; mein Haus\"\n",
+			"tab":       "word\tmeaning\texample\nHaus\tHouse\tDas Haus; mein Haus\n",
+		}
+
+		for name, content := range cases {
+			words, failed := read(t, pkg.NewCSVImporter("german"), content)
+			if len(words) != 1 || len(failed) != 0 {
+				t.Fatalf("%s: expected 1 word, got %d and %v", name, len(words), failed)
+			}
+
+			if words[0].Word.Example != "Das Haus; mein Haus" {
+				t.Errorf("%s: expected example to be read whole, got %q", name, words[0].Word.Example)
+			}
+		}
+
+		importer := pkg.NewCSVImporter("german")
+		importer.Delimiter = '|'
+		importer.ListSeparator = "/"
+
+		words, _ := read(t, importer, "word|meaning\nMann|Man/Husband\n")
+		if len(words) != 1 || len(words[0].Word.Meanings) != 2 {
+			t.Errorf("expected configured delimiter and separator, got %v", words)
+		}
+	})
+
+	t.Run("rejected lines", func(t *testing.T) {
+		content := "word,meaning\nHaus,House\n,Man\nFrau,Woman,extra\nEr,\"He\nSie,She\n"
+
+		words, failed := read(t, pkg.NewCSVImporter("german"), content)
+		if len(words) != 1 {
+			t.Errorf("expected 1 word, got %d", len(words))
+		}
+
+		lines := make([]int, 0)
+		for _, err := range failed {
+			lines = append(lines, err.Line)
+		}
+
+		if len(lines) != 3 || lines[0] != 3 || lines[1] != 4 || lines[2] != 5 {
+			t.Fatalf("expected lines 3, 4 and 5 to be rejected, got %v", failed)
+		}
+
+		if !errors.Is(failed[0], pkg.ErrMissingWord) {
+			t.Errorf("expected %v, got %v", pkg.ErrMissingWord, failed[0])
+		}
+	})
+
+	t.Run("lazy quotes", func(t *testing.T) {
+		content := "word,example\nZoll,Er ist 6\" groß\n"
+
+		_, failed := read(t, pkg.NewCSVImporter("german"), content)
+		if len(failed) != 1 {
+			t.Errorf("expected bare quote to be rejected, got %v", failed)
+		}
+
+		importer := pkg.NewCSVImporter("german")
+		importer.LazyQuotes = true
+
+		words, _ := read(t, importer, content)
+		if len(words) != 1 || words[0].Word.Example != "Er ist 6\" groß" {
+			t.Errorf("expected bare quote to be kept, got %v", words)
+		}
+	})
+
+	t.Run("unknown columns", func(t *testing.T) {
+		importer := pkg.NewCSVImporter("german")
+
+		words, failed := read(t, importer, "word,colour,meaning,Notes\nHaus,red,House,\n")
+		if len(failed) != 0 || len(words) != 1 || words[0].Word.Meaning() != "House" {
+			t.Fatalf("expected Haus to be read, got %v and %v", words, failed)
+		}
+
+		if strings.Join(importer.Ignored, " ") != "colour notes" {
+			t.Errorf("expected colour and notes to be ignored, got %v", importer.Ignored)
+		}
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		cases := map[string]error{
+			"meaning,tags\nHouse,noun\n": pkg.ErrMissingWordColumn,
+			"word,Word\nHaus,Haus\n":     pkg.ErrDuplicateColumn,
+			"":                           pkg.ErrMissingWordColumn,
+		}
+
+		for content, expected := range cases {
+			_, _, err := pkg.NewCSVImporter("german").Read(strings.NewReader(content))
+			if !errors.Is(err, expected) {
+				t.Errorf("expected %v for %q, got %v", expected, content, err)
+			}
+		}
+	})
+}
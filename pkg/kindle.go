@@ -0,0 +1,133 @@
+package pkg
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var ErrInvalidVocabulary = errors.New("not a kindle vocabulary database")
+
+const FORMAT_KINDLE = "kindle"
+
+// languageCodes maps languages to the ISO 639-1 codes Kindle uses
+var languageCodes = map[string]string{
+	"german":     "de",
+	"french":     "fr",
+	"spanish":    "es",
+	"portuguese": "pt",
+	"italian":    "it",
+	"dutch":      "nl",
+	"english":    "en",
+	"japanese":   "ja",
+	"chinese":    "zh",
+	"russian":    "ru",
+}
+
+// KindleImporter reads the words looked up on a Kindle, stored with the
+// sentence they were read in by the Vocabulary Builder in vocab.db
+type KindleImporter struct {
+	Lang string
+}
+
+func NewKindleImporter(lang string) *KindleImporter {
+	return &KindleImporter{Lang: lang}
+}
+
+// Read returns draft words of the language, without meaning, the sentence
+// of their first lookup as example and the titles of the books they were
+// read in as tags
+func (i *KindleImporter) Read(reader io.Reader) ([]*ImportedWord, []*LineError, error) {
+	filename, err := writeTempFile(reader, "vocab.db")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer os.RemoveAll(filepath.Dir(filename))
+
+	conn, err := sql.Open("sqlite3", filename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer conn.Close()
+
+	code, ok := languageCodes[strings.ToLower(i.Lang)]
+	if !ok {
+		code = strings.ToLower(i.Lang)
+	}
+
+	rows, err := conn.Query(`
+        SELECT words.id, words.word, COALESCE(words.stem, ''), COALESCE(lookups.usage, ''),
+               COALESCE(book_info.title, ''), lookups.timestamp
+        FROM words
+        JOIN lookups ON lookups.word_key = words.id
+        LEFT JOIN book_info ON book_info.id = lookups.book_key
+        WHERE lower(words.lang) = ? OR lower(words.lang) LIKE ? || '-%'
+        ORDER BY words.timestamp, words.id, lookups.timestamp
+    `, code, code)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidVocabulary, err)
+	}
+
+	defer rows.Close()
+
+	words := make([]*ImportedWord, 0)
+	failed := make([]*LineError, 0)
+	found := make(map[string]*Word)
+
+	for rows.Next() {
+		var id, text, stem, usage, title string
+		var timestamp int64
+
+		if err := rows.Scan(&id, &text, &stem, &usage, &title, &timestamp); err != nil {
+			return nil, nil, err
+		}
+
+		tag := strings.Join(strings.Fields(strings.ToLower(title)), "-")
+
+		// Words looked up again only add the books they were read in
+		if word, ok := found[id]; ok {
+			if tag != "" && !hasAnyTag(word, []string{tag}) {
+				word.Tags = append(word.Tags, tag)
+			}
+			continue
+		}
+
+		// The stem is the dictionary form of the word looked up
+		if stem = strings.TrimSpace(stem); stem == "" {
+			stem = strings.TrimSpace(text)
+		}
+
+		word := &Word{
+			Lang:     i.Lang,
+			Word:     stem,
+			Meanings: make([]Meaning, 0),
+			Example:  strings.Join(strings.Fields(usage), " "),
+			Tags:     make([]string, 0),
+			Draft:    true,
+			Added:    time.UnixMilli(timestamp),
+		}
+
+		if tag != "" {
+			word.Tags = append(word.Tags, tag)
+		}
+
+		found[id] = word
+		line := len(found)
+
+		if word.Word == "" {
+			failed = append(failed, &LineError{line, ErrMissingWord})
+			continue
+		}
+
+		words = append(words, &ImportedWord{line, word})
+	}
+
+	return words, failed, rows.Err()
+}
@@ -0,0 +1,91 @@
+package pkg_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"example.com/gocab/pkg"
+	"github.com/jessevdk/go-flags"
+)
+
+func TestKindleImporter(t *testing.T) {
+	file, err := os.Open("testdata/vocab.db")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	defer file.Close()
+
+	words, failed, err := pkg.NewKindleImporter("german").Read(file)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(failed) != 0 {
+		t.Errorf("expected no rejected word, got %v", failed)
+	}
+
+	found := make([]string, 0)
+	for _, word := range words {
+		found = append(found, word.Word.Word)
+	}
+
+	if strings.Join(found, ",") != "verhaften,Ungeziefer,Haus,Träumen" {
+		t.Fatalf("expected german stems in lookup order, got %v", found)
+	}
+
+	verhaften := words[0].Word
+	if !verhaften.Draft || len(verhaften.Meanings) != 0 || verhaften.Lang != "german" {
+		t.Errorf("expected a german draft without meaning, got %v", verhaften)
+	}
+
+	if !strings.HasPrefix(verhaften.Example, "Jemand mußte Josef K.") {
+		t.Errorf("expected the first lookup as example, got %q", verhaften.Example)
+	}
+
+	if strings.Join(verhaften.Tags, ",") != "der-process,die-verwandlung" {
+		t.Errorf("expected the titles of both books as tags, got %v", verhaften.Tags)
+	}
+
+	if !verhaften.Added.Equal(time.UnixMilli(1600000000000)) {
+		t.Errorf("expected the lookup time, got %v", verhaften.Added)
+	}
+}
+
+func TestKindleImportCommand(t *testing.T) {
+	repository := newSqliteRepository(t)
+	service := pkg.NewService(repository)
+
+	service.AddWord(&pkg.Word{Lang: "german", Word: "das Haus", Meanings: pkg.ParseMeanings("House")})
+
+	writer := bytes.NewBuffer(nil)
+	cmd := pkg.CreateImportCommand(service, writer)
+
+	if _, err := flags.ParseArgs(cmd, []string{"-l", "german", "-f", "testdata/vocab.db", "--format", "kindle"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := cmd.Execute([]string{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if writer.String() != "imported 3 word(s)\nskipped 1 word(s) already registered\n" {
+		t.Errorf("expected 3 imported and 1 skipped word, got %q", writer.String())
+	}
+
+	words, _ := repository.ListWords("german", nil, "", pkg.SORT_WORD, 0)
+	if len(words) != 4 {
+		t.Fatalf("expected 4 words, got %d", len(words))
+	}
+
+	for _, word := range words {
+		if word.Word == "Haus" && (word.Draft || word.Meaning() != "House") {
+			t.Errorf("expected known word to be left as is, got %v", word)
+		} else if word.Word != "Haus" && !word.Draft {
+			t.Errorf("expected %s to be stored as draft", word.Word)
+		}
+	}
+}
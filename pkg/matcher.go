@@ -0,0 +1,116 @@
+package pkg
+
+import (
+	"math"
+)
+
+// Result grades an answer
+type Result int
+
+const (
+	WRONG Result = iota
+	// WRONG_ARTICLE answers have the right noun with the wrong article
+	WRONG_ARTICLE
+	ALMOST
+	// NORMALIZED answers match once special letters are folded
+	NORMALIZED
+	EXACT
+)
+
+func (r Result) String() string {
+	switch r {
+	case EXACT:
+		return "exact"
+	case NORMALIZED:
+		return "normalized"
+	case ALMOST:
+		return "almost"
+	case WRONG_ARTICLE:
+		return "wrong article"
+	}
+	return "wrong"
+}
+
+// Matcher accepts answers with a few typos as near misses, the number of
+// typos allowed growing with the length of the expected answer
+type Matcher struct {
+	// Ratio of the expected answer's letters that can be mistyped
+	Ratio float64
+
+	// MaxTypos caps the number of typos whatever the length
+	MaxTypos int
+
+	// Transpositions counts swapped letters as a single typo (Damerau)
+	// instead of two (Levenshtein)
+	Transpositions bool
+}
+
+var DefaultMatcher = &Matcher{Ratio: 0.2, MaxTypos: 2, Transpositions: true}
+
+func (m *Matcher) Match(answer, expected string) Result {
+	answer = fold(answer)
+	expected = fold(expected)
+
+	if answer == expected {
+		return EXACT
+	}
+
+	if answer == "" {
+		return WRONG
+	}
+
+	if editDistance([]rune(answer), []rune(expected), m.Transpositions) <= m.maxTypos(expected) {
+		return ALMOST
+	}
+
+	return WRONG
+}
+
+func (m *Matcher) maxTypos(expected string) int {
+	typos := int(math.Floor(float64(len([]rune(expected))) * m.Ratio))
+	if typos > m.MaxTypos {
+		return m.MaxTypos
+	}
+	return typos
+}
+
+// editDistance counts the insertions, deletions and substitutions turning a
+// into b, and also swaps of adjacent letters when transpositions is set
+func editDistance(a, b []rune, transpositions bool) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+
+			if transpositions && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return rows[len(a)][len(b)]
+}
+
+func min(values ...int) int {
+	smallest := values[0]
+	for _, value := range values[1:] {
+		if value < smallest {
+			smallest = value
+		}
+	}
+	return smallest
+}
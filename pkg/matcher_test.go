@@ -0,0 +1,102 @@
+package pkg_test
+
+import (
+	"strings"
+	"testing"
+
+	"example.com/gocab/pkg"
+)
+
+func TestMatcher(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		cases := []struct {
+			answer, expected string
+			result           pkg.Result
+		}{
+			{"receive", "receive", pkg.EXACT},
+			{" Receive\n", "receive", pkg.EXACT},
+			{"recieve", "receive", pkg.ALMOST},
+			{"recive", "receive", pkg.ALMOST},
+			{"reciev", "receive", pkg.WRONG},
+			{"hause", "house", pkg.ALMOST},
+			{"mouse", "house", pkg.ALMOST},
+			{"he", "she", pkg.WRONG},
+			{"cat", "car", pkg.WRONG},
+			{"", "house", pkg.WRONG},
+			{"internationalisation", "internationalization", pkg.ALMOST},
+			{"intrenationalisatoin", "internationalization", pkg.WRONG},
+		}
+
+		for _, c := range cases {
+			if result := pkg.DefaultMatcher.Match(c.answer, c.expected); result != c.result {
+				t.Errorf("expected %q for %q to be %v, got %v", c.answer, c.expected, c.result, result)
+			}
+		}
+	})
+
+	t.Run("levenshtein", func(t *testing.T) {
+		matcher := &pkg.Matcher{Ratio: 0.2, MaxTypos: 2}
+
+		if result := matcher.Match("recieve", "receive"); result != pkg.WRONG {
+			t.Errorf("expected swapped letters to count twice, got %v", result)
+		}
+
+		if result := matcher.Match("recive", "receive"); result != pkg.ALMOST {
+			t.Errorf("expected missing letter to count once, got %v", result)
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		matcher := &pkg.Matcher{Ratio: 0, MaxTypos: 2, Transpositions: true}
+
+		if result := matcher.Match("recieve", "receive"); result != pkg.WRONG {
+			t.Errorf("expected typos not to be accepted, got %v", result)
+		}
+	})
+}
+
+func TestNearMiss(t *testing.T) {
+	repository := pkg.NewInMemoryRepository()
+	service := pkg.NewService(repository)
+
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Bekommen", Meanings: pkg.ParseMeanings("receive")})
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("house")})
+
+	words, _ := repository.FindWords("german", nil)
+
+	summary := &pkg.Summary{Total: len(words)}
+	for _, word := range words {
+		question := &pkg.Question{Type: pkg.FOREIGN_TO_ENGLISH, Word: word, Answer: "recieve"}
+		if word.Word == "Haus" {
+			question.Answer = "home"
+			summary.Wrong(question)
+		} else {
+			summary.Almost(question)
+		}
+	}
+
+	expected := "\nTotal: 2, Correct: 0, Almost: 1, Mistakes: 1, Performance: 0%\n"
+	if summary.String()[:len(expected)] != expected {
+		t.Errorf("expected summary to start with %q, got %q", expected, summary.String())
+	}
+
+	if !strings.Contains(summary.String(), "recieve -> receive (almost)\n") {
+		t.Errorf("expected near miss to be listed, got %q", summary.String())
+	}
+
+	if err := service.SaveResult(summary); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	words, _ = repository.FindWords("german", nil)
+	for _, word := range words {
+		// A near miss counts as a hard review instead of a lapse
+		if word.Word == "Bekommen" && (word.Repetitions != 1 || word.Ease >= pkg.DEFAULT_EASE) {
+			t.Errorf("expected hard review of %s, got %d repetitions and ease %f", word.Word, word.Repetitions, word.Ease)
+		}
+
+		if word.Word == "Haus" && word.Repetitions != 0 {
+			t.Errorf("expected lapse of %s, got %d repetitions", word.Word, word.Repetitions)
+		}
+	}
+}
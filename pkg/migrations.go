@@ -0,0 +1,262 @@
+package pkg
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Each migration brings the schema from version i to version i+1 and runs
+// inside its own transaction. Migrations are only ever appended: editing one
+// that already shipped leaves existing databases out of sync.
+var migrations = []string{
+	// 1: initial schema. Uses IF NOT EXISTS so databases created before
+	// versioning was introduced are adopted as they are.
+	`
+    CREATE TABLE IF NOT EXISTS words (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        lang TEXT NOT NULL,
+        word TEXT NOT NULL,
+        meaning TEXT NOT NULL DEFAULT '',
+        pronunciation TEXT NOT NULL DEFAULT '',
+        example TEXT NOT NULL DEFAULT '',
+        score REAL NOT NULL DEFAULT 0,
+        UNIQUE (lang, word)
+    );
+
+    CREATE TABLE IF NOT EXISTS tags (
+        word_id INTEGER NOT NULL REFERENCES words (id) ON DELETE CASCADE,
+        tag TEXT NOT NULL
+    );
+
+    CREATE INDEX IF NOT EXISTS tags_word_id ON tags (word_id);
+    `,
+
+	// 2: spaced repetition state. Words already answered correctly keep
+	// their progress as repetitions, and every word is due right away.
+	`
+    ALTER TABLE words ADD COLUMN ease REAL NOT NULL DEFAULT 2.5;
+    ALTER TABLE words ADD COLUMN interval INTEGER NOT NULL DEFAULT 0;
+    ALTER TABLE words ADD COLUMN repetitions INTEGER NOT NULL DEFAULT 0;
+    ALTER TABLE words ADD COLUMN due INTEGER NOT NULL DEFAULT 0;
+
+    UPDATE words SET repetitions = CAST(score * 2 AS INTEGER);
+    `,
+
+	// 3: FSRS state and per language settings
+	`
+    ALTER TABLE words ADD COLUMN reviewed INTEGER NOT NULL DEFAULT 0;
+    ALTER TABLE words ADD COLUMN stability REAL NOT NULL DEFAULT 0;
+    ALTER TABLE words ADD COLUMN difficulty REAL NOT NULL DEFAULT 0;
+
+    CREATE TABLE settings (
+        lang TEXT PRIMARY KEY,
+        scheduler TEXT NOT NULL DEFAULT '',
+        retention REAL NOT NULL DEFAULT 0.9
+    );
+    `,
+
+	// 4: Leitner boxes
+	`
+    ALTER TABLE words ADD COLUMN box INTEGER NOT NULL DEFAULT 0;
+
+    ALTER TABLE settings ADD COLUMN boxes INTEGER NOT NULL DEFAULT 0;
+    ALTER TABLE settings ADD COLUMN box_intervals TEXT NOT NULL DEFAULT '';
+    `,
+
+	// 5: review history, durations are stored in milliseconds
+	`
+    CREATE TABLE reviews (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        word_id INTEGER NOT NULL REFERENCES words (id) ON DELETE CASCADE,
+        time INTEGER NOT NULL,
+        type INTEGER NOT NULL,
+        answer TEXT NOT NULL,
+        correct INTEGER NOT NULL,
+        duration INTEGER NOT NULL DEFAULT 0
+    );
+
+    CREATE INDEX reviews_word_id ON reviews (word_id);
+    `,
+
+	// 6: when words were added, existing words count as added now
+	`
+    ALTER TABLE words ADD COLUMN added INTEGER NOT NULL DEFAULT 0;
+
+    UPDATE words SET added = CAST(strftime('%s', 'now') AS INTEGER);
+    `,
+
+	// 7: full-text index over the words table. FTS4 is used as FTS5 is
+	// only compiled in with the sqlite_fts5 build tag. The index is kept
+	// in sync by triggers, removing rows before they change as required
+	// by external content tables.
+	`
+    CREATE VIRTUAL TABLE words_search USING fts4(
+        content="words", word, meaning, pronunciation, example, tokenize=unicode61
+    );
+
+    INSERT INTO words_search (words_search) VALUES ('rebuild');
+
+    CREATE TRIGGER words_search_insert AFTER INSERT ON words BEGIN
+        INSERT INTO words_search (docid, word, meaning, pronunciation, example)
+        VALUES (new.id, new.word, new.meaning, new.pronunciation, new.example);
+    END;
+
+    CREATE TRIGGER words_search_before_update BEFORE UPDATE OF word, meaning, pronunciation, example ON words BEGIN
+        DELETE FROM words_search WHERE docid = old.id;
+    END;
+
+    CREATE TRIGGER words_search_after_update AFTER UPDATE OF word, meaning, pronunciation, example ON words BEGIN
+        INSERT INTO words_search (docid, word, meaning, pronunciation, example)
+        VALUES (new.id, new.word, new.meaning, new.pronunciation, new.example);
+    END;
+
+    CREATE TRIGGER words_search_delete BEFORE DELETE ON words BEGIN
+        DELETE FROM words_search WHERE docid = old.id;
+    END;
+    `,
+
+	// 8: article of nouns
+	`
+    ALTER TABLE words ADD COLUMN article TEXT NOT NULL DEFAULT '';
+    `,
+
+	// 9: meanings of a word, each with an optional note. The words meaning
+	// column is kept with every meaning joined for the full-text index.
+	// Existing meanings are split on semicolons.
+	`
+    CREATE TABLE meanings (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        word_id INTEGER NOT NULL REFERENCES words (id) ON DELETE CASCADE,
+        text TEXT NOT NULL,
+        note TEXT NOT NULL DEFAULT ''
+    );
+
+    CREATE INDEX meanings_word_id ON meanings (word_id);
+
+    INSERT INTO meanings (word_id, text)
+    WITH RECURSIVE split (word_id, position, text, rest) AS (
+        SELECT id, 0, '', meaning || ';' FROM words
+        UNION ALL
+        SELECT word_id, position + 1, trim(substr(rest, 1, instr(rest, ';') - 1)), substr(rest, instr(rest, ';') + 1)
+        FROM split WHERE rest <> ''
+    )
+    SELECT word_id, text FROM split WHERE text <> '' ORDER BY word_id, position;
+    `,
+
+	// 10: draft words, imported without a meaning yet
+	`
+    ALTER TABLE words ADD COLUMN draft INTEGER NOT NULL DEFAULT 0;
+    `,
+
+	// 11: users sharing the word lists, each with their own scheduling
+	// state and review history. Existing progress and reviews belong to
+	// the default user.
+	`
+    CREATE TABLE users (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        name TEXT NOT NULL UNIQUE
+    );
+
+    INSERT INTO users (name) VALUES ('default');
+
+    CREATE TABLE progress (
+        user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+        word_id INTEGER NOT NULL REFERENCES words (id) ON DELETE CASCADE,
+        score REAL NOT NULL DEFAULT 0,
+        ease REAL NOT NULL DEFAULT 2.5,
+        interval INTEGER NOT NULL DEFAULT 0,
+        repetitions INTEGER NOT NULL DEFAULT 0,
+        due INTEGER NOT NULL DEFAULT 0,
+        reviewed INTEGER NOT NULL DEFAULT 0,
+        stability REAL NOT NULL DEFAULT 0,
+        difficulty REAL NOT NULL DEFAULT 0,
+        box INTEGER NOT NULL DEFAULT 0,
+        PRIMARY KEY (user_id, word_id)
+    );
+
+    CREATE INDEX progress_word_id ON progress (word_id);
+
+    INSERT INTO progress (user_id, word_id, score, ease, interval, repetitions, due, reviewed, stability, difficulty, box)
+    SELECT 1, id, score, ease, interval, repetitions, due, reviewed, stability, difficulty, box FROM words;
+
+    ALTER TABLE words DROP COLUMN score;
+    ALTER TABLE words DROP COLUMN ease;
+    ALTER TABLE words DROP COLUMN interval;
+    ALTER TABLE words DROP COLUMN repetitions;
+    ALTER TABLE words DROP COLUMN due;
+    ALTER TABLE words DROP COLUMN reviewed;
+    ALTER TABLE words DROP COLUMN stability;
+    ALTER TABLE words DROP COLUMN difficulty;
+    ALTER TABLE words DROP COLUMN box;
+
+    ALTER TABLE reviews ADD COLUMN user_id INTEGER NOT NULL DEFAULT 1;
+
+    CREATE INDEX reviews_user_id ON reviews (user_id, word_id);
+    `,
+
+	// 12: API tokens, stored as their sha256 hash
+	`
+    CREATE TABLE tokens (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+        name TEXT NOT NULL DEFAULT '',
+        scope TEXT NOT NULL,
+        hash TEXT NOT NULL UNIQUE,
+        created INTEGER NOT NULL
+    );
+    `,
+}
+
+func (r *SqliteRepository) migrate() error {
+	_, err := r.conn.Exec("CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)")
+	if err != nil {
+		return err
+	}
+
+	version, err := r.schemaVersion()
+	if err != nil {
+		return err
+	}
+
+	if version > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than supported version %d", version, len(migrations))
+	}
+
+	for ; version < len(migrations); version++ {
+		if err := r.applyMigration(version); err != nil {
+			return fmt.Errorf("could not apply migration %d: %w", version+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *SqliteRepository) schemaVersion() (int, error) {
+	var version sql.NullInt64
+
+	row := r.conn.QueryRow("SELECT MAX(version) FROM schema_version")
+	if err := row.Scan(&version); err != nil {
+		return 0, err
+	}
+
+	return int(version.Int64), nil
+}
+
+func (r *SqliteRepository) applyMigration(version int) error {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(migrations[version]); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.Exec("INSERT INTO schema_version (version) VALUES (?)", version+1); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,284 @@
+package pkg
+
+import (
+	"bytes"
+	"errors"
+	"html"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidSelection = errors.New("invalid selection")
+
+// Formats of the files words are mined from
+const FORMAT_SRT = "srt"
+const FORMAT_VTT = "vtt"
+const FORMAT_TEXT = "txt"
+
+// MinedWord is a word found in a text, in the form it was seen the most
+type MinedWord struct {
+	Word  string
+	Count int
+
+	// Example is the first sentence the word was seen in
+	Example string
+}
+
+// GuessFormat tells the format of a file from its extension, files other
+// than subtitles being read as plain text
+func GuessFormat(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".srt":
+		return FORMAT_SRT
+	case ".vtt":
+		return FORMAT_VTT
+	}
+	return FORMAT_TEXT
+}
+
+// ReadSentences splits a text or the cues of subtitles into sentences
+func ReadSentences(reader io.Reader, format string) ([]string, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.ReplaceAll(string(bytes.TrimPrefix(content, byteOrderMark)), "\r\n", "\n")
+
+	switch format {
+	case FORMAT_SRT, FORMAT_VTT:
+		// Sentences often go on over several cues
+		return splitSentences(strings.Join(readCues(text), " ")), nil
+	case FORMAT_TEXT:
+		return splitSentences(text), nil
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+var subtitleTags = regexp.MustCompile(`<[^>]*>|\{[^}]*\}`)
+
+// readCues returns the text of every cue of srt and vtt subtitles, both
+// writing cues as blocks holding their timing followed by their text. Other
+// blocks, such as the vtt header, notes and styles, are left out.
+func readCues(text string) []string {
+	cues := make([]string, 0)
+
+	for _, block := range strings.Split(text, "\n\n") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+
+		timing := -1
+		for i, line := range lines {
+			if strings.Contains(line, "-->") {
+				timing = i
+				break
+			}
+		}
+
+		if timing < 0 {
+			continue
+		}
+
+		cue := make([]string, 0)
+		for _, line := range lines[timing+1:] {
+			line = html.UnescapeString(subtitleTags.ReplaceAllString(line, ""))
+
+			// Dashes mark the lines of different speakers
+			line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-–"))
+
+			if line != "" {
+				cue = append(cue, line)
+			}
+		}
+
+		if len(cue) > 0 {
+			cues = append(cues, strings.Join(cue, " "))
+		}
+	}
+
+	return cues
+}
+
+var sentenceEnd = regexp.MustCompile(`[.!?…]+["'»«“”„)\]]*\s+`)
+
+// splitSentences splits paragraphs into sentences ending with a
+// punctuation mark followed by a space
+func splitSentences(text string) []string {
+	sentences := make([]string, 0)
+
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.Join(strings.Fields(paragraph), " ") + " "
+
+		last := 0
+		for _, end := range sentenceEnd.FindAllStringIndex(paragraph, -1) {
+			if sentence := strings.TrimSpace(paragraph[last:end[1]]); sentence != "" {
+				sentences = append(sentences, sentence)
+			}
+			last = end[1]
+		}
+
+		if sentence := strings.TrimSpace(paragraph[last:]); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+	}
+
+	return sentences
+}
+
+// MineWords ranks the words of sentences by frequency, inflections of a
+// word being counted together and known words being left out. A word is
+// known when it, its lowercase or capitalized form or its stem is.
+func MineWords(sentences []string, known func(word string) (bool, error)) ([]*MinedWord, error) {
+	type group struct {
+		forms   []string
+		counts  map[string]int
+		count   int
+		example string
+	}
+
+	groups := make(map[string]*group)
+	order := make([]string, 0)
+
+	for _, sentence := range sentences {
+		for _, token := range tokenize(sentence) {
+			if len([]rune(token.text)) < 2 || strings.IndexFunc(token.text, unicode.IsDigit) >= 0 {
+				continue
+			}
+
+			key := strings.ToLower(token.text)
+			if stem := stemOf(key); len([]rune(stem)) >= 3 {
+				key = stem
+			}
+
+			g, ok := groups[key]
+			if !ok {
+				g = &group{counts: make(map[string]int), example: sentence}
+				groups[key] = g
+				order = append(order, key)
+			}
+
+			if g.counts[token.text] == 0 {
+				g.forms = append(g.forms, token.text)
+			}
+
+			g.counts[token.text]++
+			g.count++
+		}
+	}
+
+	words := make([]*MinedWord, 0)
+
+	for _, key := range order {
+		g := groups[key]
+
+		isKnown := false
+		for _, form := range g.forms {
+			for _, variant := range foldings(form) {
+				exists, err := known(variant)
+				if err != nil {
+					return nil, err
+				}
+				isKnown = isKnown || exists
+			}
+		}
+
+		if isKnown {
+			continue
+		}
+
+		word := g.forms[0]
+		for _, form := range g.forms {
+			if g.counts[form] > g.counts[word] {
+				word = form
+			}
+		}
+
+		words = append(words, &MinedWord{Word: word, Count: g.count, Example: g.example})
+	}
+
+	// Words seen as often keep the order they were first seen in
+	sort.SliceStable(words, func(i, j int) bool {
+		return words[i].Count > words[j].Count
+	})
+
+	return words, nil
+}
+
+// foldings returns the forms a word may be registered in
+func foldings(word string) []string {
+	lower := strings.ToLower(word)
+	forms := []string{word, lower, capitalize(lower)}
+
+	if stem := stemOf(lower); stem != lower && len([]rune(stem)) >= 3 {
+		forms = append(forms, stem, capitalize(stem))
+	}
+
+	unique := make([]string, 0, len(forms))
+	seen := make(map[string]bool)
+
+	for _, form := range forms {
+		if !seen[form] {
+			seen[form] = true
+			unique = append(unique, form)
+		}
+	}
+
+	return unique
+}
+
+func capitalize(word string) string {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return word
+	}
+	return string(unicode.ToUpper(runes[0])) + string(runes[1:])
+}
+
+// ParseSelection reads the numbers, starting at 1, of the picked items out
+// of n, as in "1 3 5-7", or "all"
+func ParseSelection(text string, n int) ([]int, error) {
+	selected := make([]int, 0)
+	seen := make(map[int]bool)
+
+	if strings.TrimSpace(strings.ToLower(text)) == "all" {
+		for i := 1; i <= n; i++ {
+			selected = append(selected, i)
+		}
+		return selected, nil
+	}
+
+	parts := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	for _, part := range parts {
+		first, last, isRange := strings.Cut(part, "-")
+		if !isRange {
+			last = first
+		}
+
+		from, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, ErrInvalidSelection
+		}
+
+		to, err := strconv.Atoi(last)
+		if err != nil || from < 1 || to > n || from > to {
+			return nil, ErrInvalidSelection
+		}
+
+		for i := from; i <= to; i++ {
+			if !seen[i] {
+				seen[i] = true
+				selected = append(selected, i)
+			}
+		}
+	}
+
+	return selected, nil
+}
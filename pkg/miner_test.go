@@ -0,0 +1,137 @@
+package pkg_test
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"example.com/gocab/pkg"
+	"github.com/jessevdk/go-flags"
+)
+
+func TestReadSentences(t *testing.T) {
+	cases := map[string]struct {
+		format  string
+		content string
+	}{
+		"srt": {pkg.FORMAT_SRT, "1\r\n00:00:01,000 --> 00:00:03,000\r\n<i>Als Gregor Samsa eines Morgens</i>\r\n\r\n2\r\n00:00:03,500 --> 00:00:05,000\r\naus unruhigen Träumen erwachte.\r\n- Wer ist da?\r\n"},
+		"vtt": {pkg.FORMAT_VTT, "WEBVTT\n\nNOTE translated by hand\n\nintro\n00:01.000 --> 00:03.000 align:start\n<v Erzähler>Als Gregor Samsa eines Morgens\naus unruhigen Träumen erwachte.</v>\n\n00:03.500 --> 00:05.000\n&lt;Wer&gt; ist da?\n"},
+		"txt": {pkg.FORMAT_TEXT, "\ufeffAls Gregor Samsa eines Morgens\naus unruhigen Träumen erwachte.  Wer ist da?\n\nEnde"},
+	}
+
+	expected := map[string][]string{
+		"srt": {"Als Gregor Samsa eines Morgens aus unruhigen Träumen erwachte.", "Wer ist da?"},
+		"vtt": {"Als Gregor Samsa eines Morgens aus unruhigen Träumen erwachte.", "<Wer> ist da?"},
+		"txt": {"Als Gregor Samsa eines Morgens aus unruhigen Träumen erwachte.", "Wer ist da?", "Ende"},
+	}
+
+	for name, c := range cases {
+		sentences, err := pkg.ReadSentences(strings.NewReader(c.content), c.format)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", name, err)
+		}
+
+		if strings.Join(sentences, "|") != strings.Join(expected[name], "|") {
+			t.Errorf("%s: expected %q, got %q", name, expected[name], sentences)
+		}
+	}
+
+	if format := pkg.GuessFormat("episode.VTT"); format != pkg.FORMAT_VTT {
+		t.Errorf("expected %s, got %s", pkg.FORMAT_VTT, format)
+	}
+}
+
+func TestMineWords(t *testing.T) {
+	known := map[string]bool{"Haus": true, "gehen": true}
+
+	sentences := []string{
+		"Wir gehen nach Hause.",
+		"Das Haus ist alt, 1900 gebaut.",
+		"Der Hund bellt. Die Hunde bellen!",
+		"Ein Hund.",
+	}
+
+	words, err := pkg.MineWords(sentences, func(word string) (bool, error) {
+		return known[word], nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	found := make([]string, 0)
+	for _, word := range words {
+		found = append(found, word.Word)
+	}
+
+	if found[0] != "Hund" || words[0].Count != 3 || words[0].Example != "Der Hund bellt. Die Hunde bellen!" {
+		t.Errorf("expected Hund seen 3 times first, got %v", words[0])
+	}
+
+	for _, word := range found {
+		if word == "gehen" || word == "Hause" || word == "Haus" || word == "1900" {
+			t.Errorf("expected %s to be left out, got %v", word, found)
+		}
+	}
+}
+
+func TestParseSelection(t *testing.T) {
+	selected, err := pkg.ParseSelection("1, 3 5-7 3\n", 8)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(selected) != 5 || selected[0] != 1 || selected[1] != 3 || selected[4] != 7 {
+		t.Errorf("expected 1, 3 and 5 to 7, got %v", selected)
+	}
+
+	if selected, _ := pkg.ParseSelection("all", 3); len(selected) != 3 {
+		t.Errorf("expected every item, got %v", selected)
+	}
+
+	for _, text := range []string{"0", "9", "x", "3-1"} {
+		if _, err := pkg.ParseSelection(text, 8); err != pkg.ErrInvalidSelection {
+			t.Errorf("expected error %v for %q, got %v", pkg.ErrInvalidSelection, text, err)
+		}
+	}
+}
+
+func TestMineCommand(t *testing.T) {
+	filename := path.Join(t.TempDir(), "episode.srt")
+	content := "1\n00:00:01,000 --> 00:00:03,000\nHunde bellen.\n\n2\n00:00:04,000 --> 00:00:06,000\nDie Hunde bellen im Haus.\n"
+
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	repository := pkg.NewInMemoryRepository()
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House")})
+
+	reader := bytes.NewBuffer([]byte("1-2\n"))
+	writer := bytes.NewBuffer(nil)
+	cmd := pkg.CreateMineCommand(pkg.NewService(repository), reader, writer)
+
+	if _, err := flags.ParseArgs(cmd, []string{"-l", "german", "-f", filename, "-t", "episode", "--min-count", "2"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := cmd.Execute([]string{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if strings.Contains(writer.String(), "Haus") || !strings.HasSuffix(writer.String(), "added 2 draft(s)\n") {
+		t.Errorf("expected 2 new words to be added, got %q", writer.String())
+	}
+
+	words, _ := repository.ListWords("german", []string{"episode"}, "", pkg.SORT_WORD, 0)
+	if len(words) != 2 {
+		t.Fatalf("expected 2 words, got %d", len(words))
+	}
+
+	for _, word := range words {
+		if !word.Draft || word.Example != "Hunde bellen." {
+			t.Errorf("expected a draft with its first sentence, got %v", word)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package pkg
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizer folds answers typed without the language's special letters,
+// so "uber" or "ueber" can be accepted for "über"
+type Normalizer struct {
+	// Transliteration spells out special letters, as ü in ue
+	Transliteration *strings.Replacer
+
+	// StripAccents removes diacritics, as in über to uber
+	StripAccents bool
+}
+
+var normalizers = map[string]*Normalizer{
+	"german": {
+		Transliteration: strings.NewReplacer("ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss"),
+		StripAccents:    true,
+	},
+	"spanish": {
+		StripAccents: true,
+	},
+	"portuguese": {
+		StripAccents: true,
+	},
+	"french": {
+		Transliteration: strings.NewReplacer("œ", "oe", "æ", "ae"),
+		StripAccents:    true,
+	},
+}
+
+var defaultNormalizer = &Normalizer{StripAccents: true}
+
+// NormalizerFor returns the normalizer of a language, languages without
+// their own only having diacritics stripped
+func NormalizerFor(lang string) *Normalizer {
+	if normalizer, ok := normalizers[strings.ToLower(lang)]; ok {
+		return normalizer
+	}
+	return defaultNormalizer
+}
+
+// fold puts text in its composed, lowercase form so the same letters
+// typed as a single code point or with combining marks compare equal
+func fold(text string) string {
+	return norm.NFC.String(strings.ToLower(strings.TrimSpace(text)))
+}
+
+// Forms returns the ways text can be spelled without special letters
+func (n *Normalizer) Forms(text string) []string {
+	text = fold(text)
+	forms := []string{text}
+
+	if n.Transliteration != nil {
+		forms = append(forms, n.Transliteration.Replace(text))
+	}
+
+	if n.StripAccents {
+		forms = append(forms, stripAccents(text))
+	}
+
+	return forms
+}
+
+// stripAccents decomposes letters and drops their combining marks
+func stripAccents(text string) string {
+	var builder strings.Builder
+
+	for _, r := range norm.NFD.String(text) {
+		if !unicode.Is(unicode.Mn, r) {
+			builder.WriteRune(r)
+		}
+	}
+
+	return norm.NFC.String(builder.String())
+}
@@ -0,0 +1,107 @@
+package pkg_test
+
+import (
+	"strings"
+	"testing"
+
+	"example.com/gocab/pkg"
+)
+
+func TestNormalizedAnswers(t *testing.T) {
+	check := func(lang, word, answer string, strict bool) pkg.Result {
+		question := &pkg.Question{
+			Type:   pkg.ENGLISH_TO_FOREIGN,
+			Word:   &pkg.Word{Lang: lang, Word: word, Meanings: pkg.ParseMeanings("meaning")},
+			Answer: answer,
+			Strict: strict,
+		}
+		return question.Check()
+	}
+
+	t.Run("german", func(t *testing.T) {
+		cases := []struct {
+			word, answer string
+			result       pkg.Result
+		}{
+			{"über", "über", pkg.EXACT},
+			{"über", "uber", pkg.NORMALIZED},
+			{"über", "ueber", pkg.NORMALIZED},
+			{"Straße", "strasse", pkg.NORMALIZED},
+			{"Straße", "Strase", pkg.ALMOST},
+			{"Mädchen", "Maedchen", pkg.NORMALIZED},
+			{"Mädchen", "Junge", pkg.WRONG},
+		}
+
+		for _, c := range cases {
+			if result := check("german", c.word, c.answer, false); result != c.result {
+				t.Errorf("expected %q for %q to be %v, got %v", c.answer, c.word, c.result, result)
+			}
+		}
+	})
+
+	t.Run("spanish", func(t *testing.T) {
+		if result := check("spanish", "canción", "cancion", false); result != pkg.NORMALIZED {
+			t.Errorf("expected accents to be optional, got %v", result)
+		}
+
+		// Spanish has no transliteration of ñ
+		if result := check("spanish", "año", "anio", false); result == pkg.NORMALIZED {
+			t.Errorf("expected no transliteration, got %v", result)
+		}
+	})
+
+	t.Run("decomposed", func(t *testing.T) {
+		// "u" followed by a combining diaeresis
+		if result := check("german", "über", "u\u0308ber", true); result != pkg.EXACT {
+			t.Errorf("expected decomposed answer to be exact, got %v", result)
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		if result := check("german", "über", "ueber", true); result != pkg.WRONG {
+			t.Errorf("expected transliteration to be rejected, got %v", result)
+		}
+
+		if result := check("german", "Mädchen", "Madchen", true); result != pkg.ALMOST {
+			t.Errorf("expected missing accent to be a typo, got %v", result)
+		}
+	})
+
+	t.Run("summary", func(t *testing.T) {
+		question := &pkg.Question{
+			Type:   pkg.ENGLISH_TO_FOREIGN,
+			Word:   &pkg.Word{Lang: "german", Word: "über", Meanings: pkg.ParseMeanings("over")},
+			Answer: "ueber",
+		}
+
+		if !question.IsCorrect() {
+			t.Fatalf("expected normalized answer to be correct")
+		}
+
+		summary := &pkg.Summary{Total: 1}
+		summary.Correct(question)
+
+		if !strings.Contains(summary.String(), "ueber -> über (normalized)\n") {
+			t.Errorf("expected normalized answer to be listed, got %q", summary.String())
+		}
+	})
+}
+
+func TestStrictQuiz(t *testing.T) {
+	for _, strict := range []bool{false, true} {
+		repository := pkg.NewInMemoryRepository()
+		service := pkg.NewService(repository)
+		service.AddWord(&pkg.Word{Lang: "german", Word: "Straße", Meanings: pkg.ParseMeanings("street")})
+
+		questions, err := service.CreateQuiz("german", nil, pkg.QuizOptions{Strict: strict})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		for _, question := range questions {
+			if question.Strict != strict {
+				t.Errorf("expected strict to be %v, got %v", strict, question.Strict)
+			}
+		}
+	}
+}
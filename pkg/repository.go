@@ -3,50 +3,199 @@ package pkg
 import (
 	"database/sql"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// QUIZ_SIZE is the maximum number of words picked for a quiz
+const QUIZ_SIZE = 15
+
+// DEFAULT_USER owns the progress of a repository not scoped to a user, and
+// everything recorded before users were introduced
+const DEFAULT_USER = "default"
+
+// WordRepository stores the words shared by every user, scheduling state
+// and reviews being read and saved for the user the repository is scoped to
 type WordRepository interface {
+	// ForUser returns the same repository scoped to another user, who is
+	// created on first use
+	ForUser(user string) (WordRepository, error)
+
 	HasWord(lang, word string) (bool, error)
+
+	// FindWords returns the words to quiz, due words first, leaving drafts
+	// out as they have no meaning to be asked yet
 	FindWords(lang string, tags []string) ([]*Word, error)
-	AddWord(lang, word, meaning, pronunciation, example string, tags []string) (*Word, error)
-	UpdateWord(lang, word, meaning, pronunciation, example string, tags []string) (*Word, error)
+	ListWords(lang string, tags []string, level, sort string, limit int) ([]*Word, error)
+
+	// SearchWords finds words matching the query in any language when lang
+	// is empty
+	SearchWords(lang, query string) ([]*SearchResult, error)
+	// AddWord stores a new word along with any scheduling state it
+	// carries, as words imported from a backup do
+	AddWord(word *Word) (*Word, error)
+
+	// UpdateWord replaces the definition of a word, keeping its scheduling
+	// state
+	UpdateWord(word *Word) (*Word, error)
+	DeleteWord(lang, word string) error
 	SaveResult(summary *Summary) error
+	FindSettings(lang string) (*Settings, error)
+	SaveSettings(settings *Settings) error
+
+	// FindReviews returns the answers given for a word, or for every word
+	// of the language when word is empty, most recent first
+	FindReviews(lang, word string) ([]*Review, error)
 }
 
 type InMemoryRepository struct {
-	words map[string]map[string]Word
+	*memoryStore
+	user string
+}
+
+// memoryStore holds what the users of an InMemoryRepository share, the
+// words keeping the definition and progress the scheduling state of every
+// user, by user, language and word
+type memoryStore struct {
+	words    map[string]map[string]Word
+	progress map[string]map[string]map[string]Word
+	settings map[string]Settings
+	reviews  map[string][]Review
+	tokens   []Token
 }
 
 func NewInMemoryRepository() *InMemoryRepository {
-	return &InMemoryRepository{
-		words: make(map[string]map[string]Word),
+	store := &memoryStore{
+		words:    make(map[string]map[string]Word),
+		progress: make(map[string]map[string]map[string]Word),
+		settings: make(map[string]Settings),
+		reviews:  make(map[string][]Review),
 	}
+
+	return &InMemoryRepository{store, DEFAULT_USER}
 }
 
-func (r *InMemoryRepository) AddWord(lang, word, meaning, pronunciation, example string, tags []string) (*Word, error) {
-	if _, ok := r.words[lang]; !ok {
-		r.words[lang] = make(map[string]Word)
+func (r *InMemoryRepository) ForUser(user string) (WordRepository, error) {
+	user = strings.TrimSpace(user)
+	if user == "" {
+		return nil, ErrInvalidUser
 	}
 
-	w := Word{lang, word, meaning, pronunciation, example, tags, 0}
-	r.words[lang][word] = w
+	return &InMemoryRepository{r.memoryStore, user}, nil
+}
+
+func (r *InMemoryRepository) AddWord(word *Word) (*Word, error) {
+	if _, ok := r.words[word.Lang]; !ok {
+		r.words[word.Lang] = make(map[string]Word)
+	}
+
+	w := newWord(word)
+	r.words[word.Lang][word.Word] = *w
+	r.saveProgress(w)
 
+	return w, nil
+}
+
+// saveProgress keeps the scheduling state of a word for the user
+func (r *InMemoryRepository) saveProgress(word *Word) {
+	if _, ok := r.progress[r.user]; !ok {
+		r.progress[r.user] = make(map[string]map[string]Word)
+	}
+
+	if _, ok := r.progress[r.user][word.Lang]; !ok {
+		r.progress[r.user][word.Lang] = make(map[string]Word)
+	}
+
+	r.progress[r.user][word.Lang][word.Word] = *word
+}
+
+// withProgress copies a stored word along with the scheduling state of the
+// user, words the user never saw being new to them
+func (r *InMemoryRepository) withProgress(word Word) Word {
+	progress, ok := r.progress[r.user][word.Lang][word.Word]
+	if !ok {
+		progress = Word{Ease: DEFAULT_EASE}
+	}
+
+	word.Score = progress.Score
+	word.Ease = progress.Ease
+	word.Interval = progress.Interval
+	word.Repetitions = progress.Repetitions
+	word.Due = progress.Due
+	word.Reviewed = progress.Reviewed
+	word.Stability = progress.Stability
+	word.Difficulty = progress.Difficulty
+	word.Box = progress.Box
+
+	return word
+}
+
+// newWord copies a word about to be added, new words being added now with
+// the default ease unless they carry their own
+func newWord(word *Word) *Word {
+	w := *word
+
+	if w.Added.IsZero() {
+		w.Added = time.Now()
+	}
+
+	if w.Ease == 0 {
+		w.Ease = DEFAULT_EASE
+	}
+
+	return &w
+}
+
+func (r *InMemoryRepository) UpdateWord(word *Word) (*Word, error) {
+	words, ok := r.words[word.Lang]
+	if !ok {
+		return nil, fmt.Errorf("no lang found: %s", word.Lang)
+	}
+
+	w, ok := words[word.Word]
+	if !ok {
+		return nil, ErrWordNotRegistered
+	}
+
+	w.Article = word.Article
+	w.Meanings = word.Meanings
+	w.Pronunciation = word.Pronunciation
+	w.Example = word.Example
+	w.Tags = word.Tags
+	w.Draft = word.Draft
+	words[word.Word] = w
+
+	w = r.withProgress(w)
 	return &w, nil
 }
 
-func (r *InMemoryRepository) UpdateWord(lang, word, meaning, pronunciation, example string, tags []string) (*Word, error) {
+func (r *InMemoryRepository) DeleteWord(lang, word string) error {
 	words, ok := r.words[lang]
 	if !ok {
-		return nil, fmt.Errorf("no lang found: %s", lang)
+		return ErrWordNotRegistered
 	}
 
-	w := Word{lang, word, meaning, pronunciation, example, tags, 0}
-	words[word] = w
+	delete(words, word)
 
-	return &w, nil
+	for user := range r.progress {
+		delete(r.progress[user][lang], word)
+	}
+
+	for user := range r.reviews {
+		reviews := make([]Review, 0, len(r.reviews[user]))
+		for _, review := range r.reviews[user] {
+			if review.Lang != lang || review.Word != word {
+				reviews = append(reviews, review)
+			}
+		}
+		r.reviews[user] = reviews
+	}
+
+	return nil
 }
 
 func (r *InMemoryRepository) FindWords(lang string, tags []string) ([]*Word, error) {
@@ -58,25 +207,119 @@ func (r *InMemoryRepository) FindWords(lang string, tags []string) ([]*Word, err
 	found := make([]*Word, 0)
 
 	for _, word := range words {
-		if len(tags) == 0 {
+		if word.Draft {
+			continue
+		}
+
+		word := r.withProgress(word)
+
+		if len(tags) == 0 || hasAnyTag(&word, tags) {
 			found = append(found, &word)
+		}
+	}
+
+	// Words due for review come first, new words being due right away
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].Due.Before(found[j].Due)
+	})
+
+	if len(found) > QUIZ_SIZE {
+		found = found[:QUIZ_SIZE]
+	}
+
+	return found, nil
+}
+
+func (r *InMemoryRepository) ListWords(lang string, tags []string, level, sortBy string, limit int) ([]*Word, error) {
+	found := make([]*Word, 0)
+
+	for _, word := range r.words[lang] {
+		word := r.withProgress(word)
+
+		if level != "" && strings.ToLower(word.Level()) != level {
 			continue
 		}
 
-	outer:
-		for _, tag := range word.Tags {
-			for _, expected := range tags {
-				if tag == expected {
-					found = append(found, &word)
-					break outer
-				}
+		if len(tags) > 0 && !hasAnyTag(&word, tags) {
+			continue
+		}
+
+		found = append(found, &word)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		switch sortBy {
+		case SORT_SCORE:
+			if found[i].Score != found[j].Score {
+				return found[i].Score < found[j].Score
+			}
+		case SORT_ADDED:
+			if !found[i].Added.Equal(found[j].Added) {
+				return found[i].Added.After(found[j].Added)
 			}
 		}
+		return strings.ToLower(found[i].Word) < strings.ToLower(found[j].Word)
+	})
+
+	if limit > 0 && len(found) > limit {
+		found = found[:limit]
 	}
 
 	return found, nil
 }
 
+func (r *InMemoryRepository) SearchWords(lang, query string) ([]*SearchResult, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil, nil
+	}
+
+	results := make([]*SearchResult, 0)
+
+	for _, words := range r.words {
+		for _, word := range words {
+			word := r.withProgress(word)
+
+			if lang != "" && word.Lang != lang {
+				continue
+			}
+
+			fields := make([]string, 0)
+			values := []string{word.Word, word.Meaning(), word.Pronunciation, word.Example}
+
+			for i, value := range values {
+				if strings.Contains(strings.ToLower(value), query) {
+					fields = append(fields, searchFields[i])
+				}
+			}
+
+			if len(fields) > 0 {
+				results = append(results, &SearchResult{Word: &word, Fields: fields})
+			}
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Word.Lang != results[j].Word.Lang {
+			return results[i].Word.Lang < results[j].Word.Lang
+		}
+		return strings.ToLower(results[i].Word.Word) < strings.ToLower(results[j].Word.Word)
+	})
+
+	return results, nil
+}
+
+func hasAnyTag(word *Word, tags []string) bool {
+	for _, tag := range word.Tags {
+		for _, expected := range tags {
+			if tag == expected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (r *InMemoryRepository) HasWord(lang, word string) (bool, error) {
 	if list, ok := r.words[lang]; ok {
 		_, found := list[word]
@@ -86,29 +329,94 @@ func (r *InMemoryRepository) HasWord(lang, word string) (bool, error) {
 }
 
 func (r *InMemoryRepository) SaveResult(summary *Summary) error {
-	lang := summary.Questions[0].Word.Lang
-	words, ok := r.words[lang]
-	if !ok {
-		return ErrNoWordsFound
+	for _, question := range summary.Questions {
+		if _, ok := r.words[question.Word.Lang]; !ok {
+			return ErrNoWordsFound
+		}
+
+		r.saveProgress(question.Word)
+		r.reviews[r.user] = append(r.reviews[r.user], *NewReview(question))
 	}
 
-	for _, question := range summary.Questions {
-		if question.IsCorrect() {
-			if question.Word.Score < 1 {
-				question.Word.Score += 0.5
-			}
-		} else if question.Word.Score > 0 {
-			question.Word.Score -= 0.5
+	return nil
+}
+
+func (r *InMemoryRepository) FindReviews(lang, word string) ([]*Review, error) {
+	found := make([]*Review, 0)
+
+	reviews := r.reviews[r.user]
+
+	for i := len(reviews) - 1; i >= 0; i-- {
+		review := reviews[i]
+		if review.Lang == lang && (word == "" || review.Word == word) {
+			found = append(found, &review)
+		}
+	}
+
+	return found, nil
+}
+
+func (r *InMemoryRepository) AddToken(token *Token) (*Token, error) {
+	t := *token
+	t.ID = 1
+	if len(r.tokens) > 0 {
+		t.ID = r.tokens[len(r.tokens)-1].ID + 1
+	}
+
+	r.tokens = append(r.tokens, t)
+
+	return &t, nil
+}
+
+func (r *InMemoryRepository) FindToken(hash string) (*Token, error) {
+	for _, token := range r.tokens {
+		if token.Hash == hash {
+			return &token, nil
 		}
+	}
+	return nil, ErrTokenNotFound
+}
+
+func (r *InMemoryRepository) ListTokens(user string) ([]*Token, error) {
+	found := make([]*Token, 0)
+
+	for _, token := range r.tokens {
+		token := token
 
-		words[question.Word.Word] = *question.Word
+		if user == "" || token.User == user {
+			found = append(found, &token)
+		}
 	}
 
+	return found, nil
+}
+
+func (r *InMemoryRepository) DeleteToken(id int64) error {
+	for i, token := range r.tokens {
+		if token.ID == id {
+			r.tokens = append(r.tokens[:i], r.tokens[i+1:]...)
+			return nil
+		}
+	}
+	return ErrTokenNotFound
+}
+
+func (r *InMemoryRepository) FindSettings(lang string) (*Settings, error) {
+	settings, ok := r.settings[lang]
+	if !ok {
+		return &Settings{Lang: lang, Retention: DEFAULT_RETENTION}, nil
+	}
+	return &settings, nil
+}
+
+func (r *InMemoryRepository) SaveSettings(settings *Settings) error {
+	r.settings[settings.Lang] = *settings
 	return nil
 }
 
 type SqliteRepository struct {
 	conn *sql.DB
+	user int64
 }
 
 func NewSqliteRepository(filename string) (*SqliteRepository, error) {
@@ -116,28 +424,81 @@ func NewSqliteRepository(filename string) (*SqliteRepository, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &SqliteRepository{conn}, nil
+
+	repository := &SqliteRepository{conn: conn}
+	if err := repository.migrate(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	repository.user, err = repository.userID(DEFAULT_USER)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+// ForUser returns a repository sharing the connection, closing either one
+// closing both
+func (r *SqliteRepository) ForUser(user string) (WordRepository, error) {
+	user = strings.TrimSpace(user)
+	if user == "" {
+		return nil, ErrInvalidUser
+	}
+
+	id, err := r.userID(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SqliteRepository{conn: r.conn, user: id}, nil
+}
+
+// userID finds the id of a user, creating them if needed
+func (r *SqliteRepository) userID(name string) (int64, error) {
+	var id int64
+
+	err := r.conn.QueryRow("SELECT id FROM users WHERE name = ?", name).Scan(&id)
+	if err != sql.ErrNoRows {
+		return id, err
+	}
+
+	result, err := r.conn.Exec("INSERT INTO users (name) VALUES (?)", name)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastInsertId()
 }
 
 func (r *SqliteRepository) Close() {
 	r.conn.Close()
 }
 
-func (r *SqliteRepository) AddWord(lang, word, meaning, pronunciation, example string, tags []string) (*Word, error) {
+func (r *SqliteRepository) AddWord(word *Word) (*Word, error) {
 	tx, err := r.conn.Begin()
 	if err != nil {
 		return nil, err
 	}
 
-	insertStmt, err := tx.Prepare("INSERT INTO words (lang, word, meaning, pronunciation, example) VALUES (?, ?, ?, ?, ?)")
+	insertStmt, err := tx.Prepare(`
+        INSERT INTO words (lang, word, article, meaning, pronunciation, example, draft, added)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+    `)
 	if err != nil {
+		tx.Rollback()
 		return nil, err
 	}
 
 	defer insertStmt.Close()
 
-	result, err := insertStmt.Exec(lang, word, meaning, pronunciation, example)
+	w := newWord(word)
+
+	result, err := insertStmt.Exec(w.Lang, w.Word, w.Article, w.Meaning(), w.Pronunciation, w.Example, w.Draft, w.Added.Unix())
 	if err != nil {
+		tx.Rollback()
 		return nil, err
 	}
 
@@ -147,7 +508,17 @@ func (r *SqliteRepository) AddWord(lang, word, meaning, pronunciation, example s
 		return nil, err
 	}
 
-	if err := r.createTags(tx, id, tags); err != nil {
+	if err := r.saveProgress(tx, w); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := r.createTags(tx, id, w.Tags); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := r.createMeanings(tx, id, w.Meanings); err != nil {
 		tx.Rollback()
 		return nil, err
 	}
@@ -156,7 +527,27 @@ func (r *SqliteRepository) AddWord(lang, word, meaning, pronunciation, example s
 		return nil, err
 	}
 
-	return &Word{lang, word, meaning, pronunciation, example, tags, 0}, nil
+	return w, nil
+}
+
+// saveProgress stores the scheduling state of a word for the user
+func (r *SqliteRepository) saveProgress(tx *sql.Tx, word *Word) error {
+	_, err := tx.Exec(`
+        INSERT INTO progress (user_id, word_id, score, ease, interval, repetitions, due, reviewed, stability, difficulty, box)
+        SELECT ?, id, ?, ?, ?, ?, ?, ?, ?, ?, ? FROM words WHERE lang = ? AND word = ?
+        ON CONFLICT (user_id, word_id) DO UPDATE SET
+            score = excluded.score,
+            ease = excluded.ease,
+            interval = excluded.interval,
+            repetitions = excluded.repetitions,
+            due = excluded.due,
+            reviewed = excluded.reviewed,
+            stability = excluded.stability,
+            difficulty = excluded.difficulty,
+            box = excluded.box
+    `, r.user, word.Score, word.Ease, word.Interval, word.Repetitions, toUnix(word.Due), toUnix(word.Reviewed), word.Stability, word.Difficulty, word.Box, word.Lang, word.Word)
+
+	return err
 }
 
 func (r *SqliteRepository) createTags(tx *sql.Tx, id int64, tags []string) error {
@@ -176,13 +567,30 @@ func (r *SqliteRepository) createTags(tx *sql.Tx, id int64, tags []string) error
 	return nil
 }
 
-func (r *SqliteRepository) UpdateWord(lang, word, meaning, pronunciation, example string, tags []string) (*Word, error) {
+func (r *SqliteRepository) createMeanings(tx *sql.Tx, id int64, meanings []Meaning) error {
+	stmt, err := tx.Prepare("INSERT INTO meanings (word_id, text, note) VALUES (?, ?, ?)")
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	for _, meaning := range meanings {
+		if _, err := stmt.Exec(id, meaning.Text, meaning.Note); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *SqliteRepository) UpdateWord(word *Word) (*Word, error) {
 	tx, err := r.conn.Begin()
 	if err != nil {
 		return nil, err
 	}
 
-	stmt, err := tx.Prepare("UPDATE words SET meaning = ?, pronunciation = ?, example = ? WHERE lang = ? AND word = ?")
+	stmt, err := tx.Prepare("UPDATE words SET article = ?, meaning = ?, pronunciation = ?, example = ?, draft = ? WHERE lang = ? AND word = ?")
 	if err != nil {
 		tx.Rollback()
 		return nil, err
@@ -190,13 +598,18 @@ func (r *SqliteRepository) UpdateWord(lang, word, meaning, pronunciation, exampl
 
 	defer stmt.Close()
 
-	_, err = stmt.Exec(meaning, pronunciation, example, lang, word)
+	_, err = stmt.Exec(word.Article, word.Meaning(), word.Pronunciation, word.Example, word.Draft, word.Lang, word.Word)
 	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
 
-	if err := r.updateTags(tx, lang, word, tags); err != nil {
+	if err := r.updateTags(tx, word.Lang, word.Word, word.Tags); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := r.updateMeanings(tx, word.Lang, word.Word, word.Meanings); err != nil {
 		tx.Rollback()
 		return nil, err
 	}
@@ -205,7 +618,43 @@ func (r *SqliteRepository) UpdateWord(lang, word, meaning, pronunciation, exampl
 		return nil, err
 	}
 
-	return &Word{lang, word, meaning, pronunciation, example, tags, 0}, nil
+	// The word is read back to return it with the progress of the user
+	words, err := r.queryWords("SELECT "+wordColumns+" FROM "+userWords+" WHERE lang = ? AND word = ?", r.user, word.Lang, word.Word)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(words) == 0 {
+		return nil, ErrWordNotRegistered
+	}
+
+	return words[0], nil
+}
+
+func (r *SqliteRepository) DeleteWord(lang, word string) error {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	// Foreign keys are not enforced by default, so rows referencing the
+	// word are removed before the word itself
+	queries := []string{
+		"DELETE FROM reviews WHERE word_id = (SELECT id FROM words WHERE lang = ? AND word = ?)",
+		"DELETE FROM progress WHERE word_id = (SELECT id FROM words WHERE lang = ? AND word = ?)",
+		"DELETE FROM tags WHERE word_id = (SELECT id FROM words WHERE lang = ? AND word = ?)",
+		"DELETE FROM meanings WHERE word_id = (SELECT id FROM words WHERE lang = ? AND word = ?)",
+		"DELETE FROM words WHERE lang = ? AND word = ?",
+	}
+
+	for _, query := range queries {
+		if _, err := tx.Exec(query, lang, word); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 func (r *SqliteRepository) updateTags(tx *sql.Tx, lang, word string, tags []string) error {
@@ -238,110 +687,327 @@ func (r *SqliteRepository) updateTags(tx *sql.Tx, lang, word string, tags []stri
 	return nil
 }
 
+func (r *SqliteRepository) updateMeanings(tx *sql.Tx, lang, word string, meanings []Meaning) error {
+	_, err := tx.Exec(`
+        DELETE FROM meanings
+        WHERE word_id = (SELECT id FROM words WHERE lang = ? AND word = ?)
+    `, lang, word)
+
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`
+        INSERT INTO meanings (word_id, text, note)
+        SELECT id, ?, ? FROM words WHERE lang = ? AND word = ?
+    `)
+
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	for _, meaning := range meanings {
+		if _, err := stmt.Exec(meaning.Text, meaning.Note, lang, word); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// wordColumns are the userWords columns read by scanWords, the meanings
+// being loaded from their own table
+const wordColumns = `
+    id, lang, word, article, pronunciation, example, draft, added, score,
+    ease, interval, repetitions, due, reviewed, stability, difficulty, box
+`
+
+// userWords selects the words along with the scheduling state of the user
+// given as first argument, words the user never saw being new to them
+var userWords = fmt.Sprintf(`(
+    SELECT words.id, words.lang, words.word, words.article, words.pronunciation, words.example, words.draft, words.added,
+        COALESCE(progress.score, 0) AS score,
+        COALESCE(progress.ease, %g) AS ease,
+        COALESCE(progress.interval, 0) AS interval,
+        COALESCE(progress.repetitions, 0) AS repetitions,
+        COALESCE(progress.due, 0) AS due,
+        COALESCE(progress.reviewed, 0) AS reviewed,
+        COALESCE(progress.stability, 0) AS stability,
+        COALESCE(progress.difficulty, 0) AS difficulty,
+        COALESCE(progress.box, 0) AS box
+    FROM words
+    LEFT JOIN progress ON progress.word_id = words.id AND progress.user_id = ?
+) AS words`, DEFAULT_EASE)
+
 func (r *SqliteRepository) FindWords(lang string, tags []string) ([]*Word, error) {
-	var err error
-	var rows *sql.Rows
+	query := "SELECT " + wordColumns + " FROM " + userWords + " WHERE lang = ? AND draft = 0"
+	args := []any{r.user, lang}
 
 	if len(tags) > 0 {
-		rows, err = r.findWordsForTags(lang, tags)
-	} else {
-		rows, err = r.findWords(lang)
+		query += " AND id IN (SELECT word_id FROM tags WHERE tag IN (?" + strings.Repeat(",?", len(tags)-1) + "))"
+		for _, tag := range tags {
+			args = append(args, tag)
+		}
 	}
 
+	// Words due for review come first, new words being due right away
+	query += " ORDER BY due, RANDOM() LIMIT ?"
+	args = append(args, QUIZ_SIZE)
+
+	return r.queryWords(query, args...)
+}
+
+func (r *SqliteRepository) ListWords(lang string, tags []string, level, sort string, limit int) ([]*Word, error) {
+	query := "SELECT " + wordColumns + " FROM " + userWords + " WHERE lang = ?"
+	args := []any{r.user, lang}
+
+	if len(tags) > 0 {
+		query += " AND id IN (SELECT word_id FROM tags WHERE tag IN (?" + strings.Repeat(",?", len(tags)-1) + "))"
+		for _, tag := range tags {
+			args = append(args, tag)
+		}
+	}
+
+	switch level {
+	case LEVEL_HARD:
+		query += " AND score < 0.5"
+	case LEVEL_MEDIUM:
+		query += " AND score >= 0.5 AND score < 1"
+	case LEVEL_EASY:
+		query += " AND score >= 1"
+	}
+
+	switch sort {
+	case SORT_SCORE:
+		query += " ORDER BY score, word COLLATE NOCASE"
+	case SORT_ADDED:
+		query += " ORDER BY added DESC, id DESC"
+	default:
+		query += " ORDER BY word COLLATE NOCASE"
+	}
+
+	if limit <= 0 {
+		limit = -1
+	}
+
+	query += " LIMIT ?"
+	args = append(args, limit)
+
+	return r.queryWords(query, args...)
+}
+
+// queryWords runs a query selecting wordColumns and loads the tags of
+// every word found
+func (r *SqliteRepository) queryWords(query string, args ...any) ([]*Word, error) {
+	rows, err := r.conn.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
 
+	defer rows.Close()
+
 	var words []*Word
+	ids := make(map[int64]*Word)
 
 	for rows.Next() {
-		var lang string
-		var word string
-		var meaning string
-		var example string
-		var pronunciation string
-		var score float64
-
-		if err := rows.Scan(&lang, &word, &meaning, &pronunciation, &example, &score); err != nil {
+		id, word, err := scanWord(rows)
+		if err != nil {
 			return nil, err
 		}
 
-		words = append(words, &Word{lang, word, meaning, pronunciation, example, nil, score})
+		ids[id] = word
+		words = append(words, word)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadTags(ids); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadMeanings(ids); err != nil {
+		return nil, err
 	}
 
 	return words, nil
 }
 
-func (r *SqliteRepository) findWords(lang string) (*sql.Rows, error) {
-	return r.conn.Query(`
-        -- Hard
-        SELECT * FROM (
-            SELECT lang, word, meaning, pronunciation, example, score
-            FROM words
-            WHERE lang = ? AND score = 0
-            ORDER BY RANDOM()
-            LIMIT 5
-        )
-        UNION ALL
-
-        -- Medium
-        SELECT * FROM (
-            SELECT lang, word, meaning, pronunciation, example, score
-            FROM words
-            WHERE lang = ? AND score = 0.5
-            ORDER BY RANDOM()
-            LIMIT 5
-        )
-        UNION ALL
-
-        -- Easy
-        SELECT * FROM (
-            SELECT lang, word, meaning, pronunciation, example, score
-            FROM words
-            WHERE lang = ? AND score = 1
-            ORDER BY RANDOM()
-            LIMIT 5
-        )
-    `, lang, lang, lang)
-}
-
-func (r *SqliteRepository) findWordsForTags(lang string, tags []string) (*sql.Rows, error) {
-	args := make([]any, 0)
-
-	for i := 0; i < 3; i++ {
+// scanWord reads a row starting with wordColumns, followed by the extra
+// columns selected by the query
+func scanWord(rows *sql.Rows, extra ...any) (int64, *Word, error) {
+	var id int64
+	var word Word
+	var added, due, reviewed int64
+
+	dest := []any{&id, &word.Lang, &word.Word, &word.Article, &word.Pronunciation, &word.Example, &word.Draft, &added, &word.Score, &word.Ease, &word.Interval, &word.Repetitions, &due, &reviewed, &word.Stability, &word.Difficulty, &word.Box}
+
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return 0, nil, err
+	}
+
+	word.Added = fromUnix(added)
+	word.Due = fromUnix(due)
+	word.Reviewed = fromUnix(reviewed)
+
+	return id, &word, nil
+}
+
+// searchFields are the columns of the words_search index, in order
+var searchFields = []string{SEARCH_WORD, SEARCH_MEANING, SEARCH_PRONUNCIATION, SEARCH_EXAMPLE}
+
+func (r *SqliteRepository) SearchWords(lang, query string) ([]*SearchResult, error) {
+	terms := make([]string, 0)
+	for _, term := range strings.Fields(strings.ReplaceAll(query, `"`, " ")) {
+		// Every term must match the start of a word in any of the fields
+		terms = append(terms, `"`+term+`*"`)
+	}
+
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	statement := `
+        SELECT ` + wordColumns + `, matches.offsets
+        FROM ` + userWords + `
+        INNER JOIN (
+            SELECT docid, offsets(words_search) AS offsets
+            FROM words_search
+            WHERE words_search MATCH ?
+        ) AS matches ON matches.docid = words.id
+    `
+	args := []any{r.user, strings.Join(terms, " ")}
+
+	if lang != "" {
+		statement += " WHERE lang = ?"
 		args = append(args, lang)
-		for _, tag := range tags {
-			args = append(args, tag)
+	}
+
+	statement += " ORDER BY lang, word COLLATE NOCASE"
+
+	rows, err := r.conn.Query(statement, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var results []*SearchResult
+	ids := make(map[int64]*Word)
+
+	for rows.Next() {
+		var offsets string
+
+		id, word, err := scanWord(rows, &offsets)
+		if err != nil {
+			return nil, err
 		}
+
+		ids[id] = word
+		results = append(results, &SearchResult{Word: word, Fields: matchedFields(offsets)})
 	}
 
-	return r.conn.Query(`
-        -- Hard
-        SELECT * FROM (
-            SELECT lang, word, meaning, pronunciation, example, score FROM words
-            WHERE lang = ? AND score = 0 AND id IN (SELECT word_id FROM tags WHERE tag IN (?`+strings.Repeat(",?", len(tags)-1)+`))
-            ORDER BY RANDOM()
-            LIMIT 5
-        )
-        UNION
-
-        -- Medium
-        SELECT * FROM (
-            SELECT lang, word, meaning, pronunciation, example, score FROM words
-            WHERE lang = ? AND score = 0.5 AND id IN (SELECT word_id FROM tags WHERE tag IN (?`+strings.Repeat(",?", len(tags)-1)+`))
-            ORDER BY RANDOM()
-            LIMIT 5
-        )
-        UNION
-
-        -- Easy
-        SELECT * FROM (
-            SELECT lang, word, meaning, pronunciation, example, score FROM words
-            WHERE lang = ? AND score = 1 AND id IN (SELECT word_id FROM tags WHERE tag IN (?`+strings.Repeat(",?", len(tags)-1)+`))
-            ORDER BY RANDOM()
-            LIMIT 5
-        )
-    `, args...)
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadTags(ids); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadMeanings(ids); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// matchedFields reads the columns out of the offsets() output, a list of
+// "column term offset size" integers for every term found
+func matchedFields(offsets string) []string {
+	found := make([]bool, len(searchFields))
+	values := strings.Fields(offsets)
+
+	for i := 0; i+3 < len(values); i += 4 {
+		column, err := strconv.Atoi(values[i])
+		if err == nil && column < len(found) {
+			found[column] = true
+		}
+	}
+
+	fields := make([]string, 0)
+	for i, field := range searchFields {
+		if found[i] {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+func (r *SqliteRepository) loadTags(words map[int64]*Word) error {
+	if len(words) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(words))
+	for id := range words {
+		args = append(args, id)
+	}
+
+	rows, err := r.conn.Query("SELECT word_id, tag FROM tags WHERE word_id IN (?"+strings.Repeat(",?", len(args)-1)+") ORDER BY rowid", args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var tag string
+
+		if err := rows.Scan(&id, &tag); err != nil {
+			return err
+		}
+
+		words[id].Tags = append(words[id].Tags, tag)
+	}
+
+	return rows.Err()
+}
+
+func (r *SqliteRepository) loadMeanings(words map[int64]*Word) error {
+	if len(words) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(words))
+	for id := range words {
+		args = append(args, id)
+	}
+
+	rows, err := r.conn.Query("SELECT word_id, text, note FROM meanings WHERE word_id IN (?"+strings.Repeat(",?", len(args)-1)+") ORDER BY id", args...)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var meaning Meaning
+
+		if err := rows.Scan(&id, &meaning.Text, &meaning.Note); err != nil {
+			return err
+		}
+
+		words[id].Meanings = append(words[id].Meanings, meaning)
+	}
+
+	return rows.Err()
 }
 
 func (r *SqliteRepository) HasWord(lang, word string) (bool, error) {
@@ -374,29 +1040,221 @@ func (r *SqliteRepository) SaveResult(summary *Summary) error {
 		return err
 	}
 
+	reviewStmt, err := tx.Prepare(`
+        INSERT INTO reviews (user_id, word_id, time, type, answer, correct, duration)
+        SELECT ?, id, ?, ?, ?, ?, ? FROM words WHERE lang = ? AND word = ?
+    `)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	defer reviewStmt.Close()
+
 	for _, question := range summary.Questions {
-		if question.IsCorrect() {
-			_, err := tx.Exec(`
-                UPDATE words SET score = score + 0.5
-                WHERE lang = ? AND word = ? AND score < 1
-            `, question.Word.Lang, question.Word.Word)
+		if err := r.saveProgress(tx, question.Word); err != nil {
+			tx.Rollback()
+			return err
+		}
 
-			if err != nil {
-				tx.Rollback()
-				return err
-			}
-		} else {
-			_, err := tx.Exec(`
-                UPDATE words SET score = score - 0.5
-                WHERE lang = ? AND word = ? AND score > 0
-            `, question.Word.Lang, question.Word.Word)
+		review := NewReview(question)
+
+		_, err := reviewStmt.Exec(r.user, review.Time.Unix(), review.Type, review.Answer, review.Correct, review.Duration.Milliseconds(), review.Lang, review.Word)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *SqliteRepository) FindReviews(lang, word string) ([]*Review, error) {
+	query := `
+        SELECT words.lang, words.word, reviews.time, reviews.type, reviews.answer, reviews.correct, reviews.duration
+        FROM reviews
+        INNER JOIN words ON words.id = reviews.word_id
+        WHERE reviews.user_id = ? AND words.lang = ?
+    `
+	args := []any{r.user, lang}
+
+	if word != "" {
+		query += " AND words.word = ?"
+		args = append(args, word)
+	}
+
+	query += " ORDER BY reviews.time DESC, reviews.id DESC"
 
+	rows, err := r.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	reviews := make([]*Review, 0)
+
+	for rows.Next() {
+		var review Review
+		var timestamp, duration int64
+
+		if err := rows.Scan(&review.Lang, &review.Word, &timestamp, &review.Type, &review.Answer, &review.Correct, &duration); err != nil {
+			return nil, err
+		}
+
+		review.Time = time.Unix(timestamp, 0)
+		review.Duration = time.Duration(duration) * time.Millisecond
+		reviews = append(reviews, &review)
+	}
+
+	return reviews, rows.Err()
+}
+
+func (r *SqliteRepository) FindSettings(lang string) (*Settings, error) {
+	var intervals string
+	settings := &Settings{Lang: lang, Retention: DEFAULT_RETENTION}
+
+	row := r.conn.QueryRow("SELECT scheduler, retention, boxes, box_intervals FROM settings WHERE lang = ?", lang)
+	if err := row.Scan(&settings.Scheduler, &settings.Retention, &settings.Boxes, &intervals); err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	if intervals != "" {
+		for _, interval := range strings.Split(intervals, ",") {
+			days, err := strconv.Atoi(interval)
 			if err != nil {
-				tx.Rollback()
-				return err
+				return nil, err
 			}
+			settings.BoxIntervals = append(settings.BoxIntervals, days)
 		}
 	}
 
-	return tx.Commit()
+	return settings, nil
+}
+
+func (r *SqliteRepository) SaveSettings(settings *Settings) error {
+	intervals := make([]string, len(settings.BoxIntervals))
+	for i, days := range settings.BoxIntervals {
+		intervals[i] = strconv.Itoa(days)
+	}
+
+	_, err := r.conn.Exec(`
+        INSERT INTO settings (lang, scheduler, retention, boxes, box_intervals) VALUES (?, ?, ?, ?, ?)
+        ON CONFLICT (lang) DO UPDATE SET
+            scheduler = excluded.scheduler,
+            retention = excluded.retention,
+            boxes = excluded.boxes,
+            box_intervals = excluded.box_intervals
+    `, settings.Lang, settings.Scheduler, settings.Retention, settings.Boxes, strings.Join(intervals, ","))
+
+	return err
+}
+
+func (r *SqliteRepository) AddToken(token *Token) (*Token, error) {
+	user, err := r.userID(token.User)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.conn.Exec(
+		"INSERT INTO tokens (user_id, name, scope, hash, created) VALUES (?, ?, ?, ?, ?)",
+		user, token.Name, token.Scope, token.Hash, token.Created.Unix(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	t := *token
+	if t.ID, err = result.LastInsertId(); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// tokenQuery selects the tokens with the columns read by queryTokens
+const tokenQuery = `
+    SELECT tokens.id, users.name, tokens.name, tokens.scope, tokens.hash, tokens.created
+    FROM tokens
+    INNER JOIN users ON users.id = tokens.user_id
+`
+
+func (r *SqliteRepository) FindToken(hash string) (*Token, error) {
+	tokens, err := r.queryTokens(tokenQuery+" WHERE tokens.hash = ?", hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, ErrTokenNotFound
+	}
+
+	return tokens[0], nil
+}
+
+func (r *SqliteRepository) ListTokens(user string) ([]*Token, error) {
+	if user == "" {
+		return r.queryTokens(tokenQuery + " ORDER BY tokens.id")
+	}
+	return r.queryTokens(tokenQuery+" WHERE users.name = ? ORDER BY tokens.id", user)
+}
+
+func (r *SqliteRepository) queryTokens(query string, args ...any) ([]*Token, error) {
+	rows, err := r.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tokens := make([]*Token, 0)
+
+	for rows.Next() {
+		var token Token
+		var created int64
+
+		if err := rows.Scan(&token.ID, &token.User, &token.Name, &token.Scope, &token.Hash, &created); err != nil {
+			return nil, err
+		}
+
+		token.Created = time.Unix(created, 0)
+		tokens = append(tokens, &token)
+	}
+
+	return tokens, rows.Err()
+}
+
+func (r *SqliteRepository) DeleteToken(id int64) error {
+	result, err := r.conn.Exec("DELETE FROM tokens WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return ErrTokenNotFound
+	}
+
+	return nil
+}
+
+// toUnix converts a time to the unix timestamps stored in the database,
+// the zero time being stored as 0
+func toUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func fromUnix(timestamp int64) time.Time {
+	if timestamp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(timestamp, 0)
 }
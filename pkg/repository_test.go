@@ -0,0 +1,600 @@
+package pkg_test
+
+import (
+	"database/sql"
+	"path"
+	"testing"
+	"time"
+
+	"example.com/gocab/pkg"
+)
+
+func newSqliteRepository(t *testing.T) *pkg.SqliteRepository {
+	t.Helper()
+
+	repository, err := pkg.NewSqliteRepository(path.Join(t.TempDir(), "database.db"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	t.Cleanup(repository.Close)
+
+	return repository
+}
+
+func TestSqliteRepository(t *testing.T) {
+	t.Run("fresh database", func(t *testing.T) {
+		repository := newSqliteRepository(t)
+
+		if _, err := repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if exists, _ := repository.HasWord("german", "Haus"); !exists {
+			t.Error("should have word \"Haus\" in german")
+		}
+	})
+
+	t.Run("reopen", func(t *testing.T) {
+		filename := path.Join(t.TempDir(), "database.db")
+
+		repository, err := pkg.NewSqliteRepository(filename)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+		repository.Close()
+
+		repository, err = pkg.NewSqliteRepository(filename)
+		if err != nil {
+			t.Fatalf("expected no error reopening, got %v", err)
+		}
+
+		defer repository.Close()
+
+		if exists, _ := repository.HasWord("german", "Haus"); !exists {
+			t.Error("should keep word \"Haus\" after reopening")
+		}
+	})
+}
+
+func TestSqliteRepositoryLegacyProgress(t *testing.T) {
+	filename := path.Join(t.TempDir(), "database.db")
+
+	// Databases created before versioning only stored a score
+	conn, err := sql.Open("sqlite3", filename)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err = conn.Exec(`
+        CREATE TABLE words (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            lang TEXT NOT NULL,
+            word TEXT NOT NULL,
+            meaning TEXT NOT NULL DEFAULT '',
+            pronunciation TEXT NOT NULL DEFAULT '',
+            example TEXT NOT NULL DEFAULT '',
+            score REAL NOT NULL DEFAULT 0,
+            UNIQUE (lang, word)
+        );
+        INSERT INTO words (lang, word, meaning, score) VALUES ('german', 'Haus', 'House', 1);
+    `)
+	conn.Close()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	repository, err := pkg.NewSqliteRepository(filename)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	defer repository.Close()
+
+	service := pkg.NewService(repository)
+	intervals := make([]int, 0)
+
+	for i := 0; i < 3; i++ {
+		words, _ := repository.FindWords("german", nil)
+
+		summary := &pkg.Summary{Total: 1}
+		summary.Correct(&pkg.Question{Type: pkg.FOREIGN_TO_ENGLISH, Word: words[0], Answer: "House"})
+
+		if err := service.SaveResult(summary); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		words, _ = repository.FindWords("german", nil)
+		intervals = append(intervals, words[0].Interval)
+	}
+
+	if intervals[0] == 0 || intervals[1] <= intervals[0] || intervals[2] <= intervals[1] {
+		t.Errorf("expected the interval to grow, got %v", intervals)
+	}
+}
+
+func TestSqliteRepositorySaveResult(t *testing.T) {
+	repository := newSqliteRepository(t)
+	service := pkg.NewService(repository)
+
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man"), Tags: []string{"noun"}})
+
+	words, err := repository.FindWords("german", nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	summary := &pkg.Summary{Total: len(words)}
+	for _, word := range words {
+		question := &pkg.Question{Type: pkg.FOREIGN_TO_ENGLISH, Word: word, Answer: word.Meaning()}
+		if word.Word == "Mann" {
+			question.Answer = "Woman"
+		}
+		summary.Correct(question)
+	}
+
+	if err := service.SaveResult(summary); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	words, err = repository.FindWords("german", []string{"noun"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(words) != 2 {
+		t.Fatalf("expected %d words, got %d", 2, len(words))
+	}
+
+	for _, word := range words {
+		if word.Due.IsZero() {
+			t.Errorf("expected %s to be scheduled", word.Word)
+		}
+
+		if word.Word == "Mann" && (word.Repetitions != 0 || word.Score != 0) {
+			t.Errorf("expected no repetitions and score 0 for %s, got %d and %f", word.Word, word.Repetitions, word.Score)
+		}
+
+		if word.Word == "Haus" && (word.Repetitions != 1 || word.Score != 0.5) {
+			t.Errorf("expected 1 repetition and score 0.5 for %s, got %d and %f", word.Word, word.Repetitions, word.Score)
+		}
+	}
+}
+
+func TestSqliteRepositoryUpdateWord(t *testing.T) {
+	repository := newSqliteRepository(t)
+	service := pkg.NewService(repository)
+
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+	words, _ := repository.FindWords("german", nil)
+
+	summary := &pkg.Summary{Total: 1}
+	summary.Correct(&pkg.Question{Type: pkg.FOREIGN_TO_ENGLISH, Word: words[0], Answer: "House"})
+
+	if err := service.SaveResult(summary); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	stored, _ := repository.FindWords("german", nil)
+
+	word, err := repository.UpdateWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House, Home"), Tags: []string{"noun"}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if word.Meaning() != "House, Home" || len(word.Tags) != 1 {
+		t.Errorf("expected Haus to be updated, got %v", word)
+	}
+
+	if !word.Added.Equal(stored[0].Added) || !word.Due.Equal(stored[0].Due) {
+		t.Errorf("expected added %v and due %v, got %v and %v", stored[0].Added, stored[0].Due, word.Added, word.Due)
+	}
+
+	if word.Interval != stored[0].Interval || word.Ease != stored[0].Ease || word.Repetitions != 1 || word.Score != stored[0].Score {
+		t.Errorf("expected the progress of Haus to be returned, got %v", word)
+	}
+
+	if _, err := repository.UpdateWord(&pkg.Word{Lang: "german", Word: "Maus"}); err != pkg.ErrWordNotRegistered {
+		t.Errorf("expected error %v, got %v", pkg.ErrWordNotRegistered, err)
+	}
+}
+
+func TestSqliteRepositorySettings(t *testing.T) {
+	repository := newSqliteRepository(t)
+
+	settings, err := repository.FindSettings("german")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if settings.Retention != pkg.DEFAULT_RETENTION {
+		t.Errorf("expected default retention, got %f", settings.Retention)
+	}
+
+	settings.Scheduler = pkg.LEITNER
+	settings.Boxes = 3
+	settings.BoxIntervals = []int{1, 3, 7}
+
+	if err := repository.SaveSettings(settings); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	settings, err = repository.FindSettings("german")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if settings.Scheduler != pkg.LEITNER || settings.Boxes != 3 || len(settings.BoxIntervals) != 3 || settings.BoxIntervals[2] != 7 {
+		t.Errorf("expected saved leitner settings, got %v", settings)
+	}
+}
+
+func TestSqliteRepositoryReviews(t *testing.T) {
+	repository := newSqliteRepository(t)
+	service := pkg.NewService(repository)
+
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House")})
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man")})
+
+	words, _ := repository.FindWords("german", nil)
+	answered := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	summary := &pkg.Summary{Total: len(words)}
+	for _, word := range words {
+		summary.Correct(&pkg.Question{
+			Type:     pkg.FOREIGN_TO_ENGLISH,
+			Word:     word,
+			Answer:   "House\n",
+			Answered: answered,
+			Duration: 1500 * time.Millisecond,
+		})
+	}
+
+	if err := service.SaveResult(summary); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	reviews, err := repository.FindReviews("german", "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(reviews) != 2 {
+		t.Fatalf("expected %d reviews, got %d", 2, len(reviews))
+	}
+
+	reviews, err = repository.FindReviews("german", "Mann")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(reviews) != 1 {
+		t.Fatalf("expected %d review, got %d", 1, len(reviews))
+	}
+
+	review := reviews[0]
+
+	if review.Correct || review.Answer != "House" || review.Type != pkg.FOREIGN_TO_ENGLISH {
+		t.Errorf("expected wrong answer %q, got %v", "House", review)
+	}
+
+	if !review.Time.Equal(answered) || review.Duration != 1500*time.Millisecond {
+		t.Errorf("expected answer at %v taking 1.5s, got %v taking %v", answered, review.Time, review.Duration)
+	}
+}
+
+func TestSqliteRepositoryListWords(t *testing.T) {
+	repository := newSqliteRepository(t)
+
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man"), Tags: []string{"noun"}})
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Er", Meanings: pkg.ParseMeanings("He"), Tags: []string{"pronoun"}})
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun", "building"}})
+
+	words, err := repository.ListWords("german", []string{"noun"}, "", pkg.SORT_WORD, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(words) != 2 || words[0].Word != "Haus" || words[1].Word != "Mann" {
+		t.Fatalf("expected Haus and Mann, got %v", words)
+	}
+
+	if len(words[0].Tags) != 2 || words[0].Tags[0] != "noun" || words[0].Tags[1] != "building" {
+		t.Errorf("expected tags %v, got %v", []string{"noun", "building"}, words[0].Tags)
+	}
+
+	words, err = repository.ListWords("german", nil, pkg.LEVEL_HARD, pkg.SORT_ADDED, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(words) != 1 || words[0].Word != "Haus" {
+		t.Errorf("expected latest word Haus, got %v", words)
+	}
+
+	words, err = repository.ListWords("german", nil, pkg.LEVEL_EASY, pkg.SORT_WORD, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(words) != 0 {
+		t.Errorf("expected no easy words, got %v", words)
+	}
+
+	words, err = repository.FindWords("german", []string{"pronoun"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(words) != 1 || len(words[0].Tags) != 1 || words[0].Tags[0] != "pronoun" {
+		t.Errorf("expected quiz words to carry their tags, got %v", words)
+	}
+}
+
+func TestSqliteRepositoryDeleteWord(t *testing.T) {
+	repository := newSqliteRepository(t)
+	service := pkg.NewService(repository)
+
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man"), Tags: []string{"noun"}})
+
+	words, _ := repository.FindWords("german", nil)
+	summary := &pkg.Summary{Total: len(words)}
+	for _, word := range words {
+		summary.Correct(&pkg.Question{Type: pkg.FOREIGN_TO_ENGLISH, Word: word, Answer: word.Meaning()})
+	}
+	service.SaveResult(summary)
+
+	if err := repository.DeleteWord("german", "Haus"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if exists, _ := repository.HasWord("german", "Haus"); exists {
+		t.Error("should have deleted word \"Haus\"")
+	}
+
+	if reviews, _ := repository.FindReviews("german", ""); len(reviews) != 1 {
+		t.Errorf("expected %d review left, got %d", 1, len(reviews))
+	}
+
+	// A new word reusing the name starts without the old tags
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"building"}})
+
+	words, _ = repository.ListWords("german", []string{"noun"}, "", pkg.SORT_WORD, 0)
+	if len(words) != 1 || words[0].Word != "Mann" {
+		t.Errorf("expected only Mann tagged noun, got %v", words)
+	}
+}
+
+func TestSqliteRepositorySearchWords(t *testing.T) {
+	repository := newSqliteRepository(t)
+
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Pronunciation: "haʊs", Example: "Mein Haus ist weit weg", Tags: []string{"noun"}})
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Über", Meanings: pkg.ParseMeanings("Over; About"), Example: "Über den Wolken", Tags: []string{"preposition"}})
+	repository.AddWord(&pkg.Word{Lang: "spanish", Word: "Casa", Meanings: pkg.ParseMeanings("House"), Example: "Mi casa es tu casa", Tags: []string{"noun"}})
+
+	t.Run("every language", func(t *testing.T) {
+		results, err := repository.SearchWords("", "hous")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(results) != 2 || results[0].Word.Word != "Haus" || results[1].Word.Word != "Casa" {
+			t.Fatalf("expected Haus and Casa, got %v", results)
+		}
+
+		if len(results[0].Fields) != 1 || results[0].Fields[0] != pkg.SEARCH_MEANING {
+			t.Errorf("expected match on %s, got %v", pkg.SEARCH_MEANING, results[0].Fields)
+		}
+
+		if len(results[0].Word.Tags) != 1 || results[0].Word.Tags[0] != "noun" {
+			t.Errorf("expected tags %v, got %v", []string{"noun"}, results[0].Word.Tags)
+		}
+	})
+
+	t.Run("language", func(t *testing.T) {
+		results, err := repository.SearchWords("german", "haus")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(results) != 1 {
+			t.Fatalf("expected %d result, got %d", 1, len(results))
+		}
+
+		fields := results[0].Fields
+		if len(fields) != 2 || fields[0] != pkg.SEARCH_WORD || fields[1] != pkg.SEARCH_EXAMPLE {
+			t.Errorf("expected match on word and example, got %v", fields)
+		}
+	})
+
+	t.Run("diacritics", func(t *testing.T) {
+		results, err := repository.SearchWords("german", "uber")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(results) != 1 || results[0].Word.Word != "Über" {
+			t.Errorf("expected Über, got %v", results)
+		}
+	})
+
+	t.Run("updated", func(t *testing.T) {
+		repository.UpdateWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("Home"), Tags: []string{"noun"}})
+
+		results, _ := repository.SearchWords("german", "home")
+		if len(results) != 1 {
+			t.Errorf("expected updated meaning to be found, got %v", results)
+		}
+
+		results, _ = repository.SearchWords("german", "weit")
+		if len(results) != 0 {
+			t.Errorf("expected removed example not to be found, got %v", results)
+		}
+	})
+}
+
+func TestSqliteRepositoryMeanings(t *testing.T) {
+	repository := newSqliteRepository(t)
+
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man; Husband (spouse)")})
+
+	words, _ := repository.ListWords("german", nil, "", pkg.SORT_WORD, 0)
+	if len(words) != 1 || len(words[0].Meanings) != 2 || words[0].Meanings[1].Note != "spouse" {
+		t.Fatalf("expected two meanings with a note, got %v", words)
+	}
+
+	repository.UpdateWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man")})
+
+	words, _ = repository.ListWords("german", nil, "", pkg.SORT_WORD, 0)
+	if words[0].Meaning() != "Man" {
+		t.Errorf("expected meanings to be replaced, got %s", words[0].Meaning())
+	}
+
+	results, _ := repository.SearchWords("german", "man")
+	if len(results) != 1 {
+		t.Errorf("expected meanings to be searchable, got %d results", len(results))
+	}
+}
+
+// testUsers checks that users share the words of a repository but not
+// their progress
+func testUsers(t *testing.T, repository pkg.WordRepository) {
+	t.Helper()
+
+	alice, err := pkg.NewService(repository).ForUser("alice")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	bob, err := pkg.NewService(repository).ForUser("bob")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	alice.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+
+	words, _ := bob.ListWords("german", nil, "", "", 0)
+	if len(words) != 1 || words[0].Word != "Haus" {
+		t.Fatalf("expected words to be shared, got %v", words)
+	}
+
+	summary := &pkg.Summary{Total: 1}
+	summary.Correct(&pkg.Question{Type: pkg.FOREIGN_TO_ENGLISH, Word: words[0], Answer: "House"})
+
+	if err := bob.SaveResult(summary); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	words, _ = bob.ListWords("german", nil, "", "", 0)
+	if words[0].Repetitions != 1 || words[0].Due.IsZero() {
+		t.Errorf("expected bob to have reviewed Haus, got %d repetitions due %v", words[0].Repetitions, words[0].Due)
+	}
+
+	words, _ = alice.ListWords("german", nil, "", "", 0)
+	if words[0].Repetitions != 0 || !words[0].Due.IsZero() || words[0].Ease != pkg.DEFAULT_EASE {
+		t.Errorf("expected alice to keep Haus new, got %d repetitions due %v", words[0].Repetitions, words[0].Due)
+	}
+
+	scoped, err := repository.ForUser("alice")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if reviews, _ := scoped.FindReviews("german", "Haus"); len(reviews) != 0 {
+		t.Errorf("expected no reviews for alice, got %d", len(reviews))
+	}
+
+	if reviews, _ := repository.FindReviews("german", "Haus"); len(reviews) != 0 {
+		t.Errorf("expected no reviews for the default user, got %d", len(reviews))
+	}
+
+	if _, err := repository.ForUser(" "); err != pkg.ErrInvalidUser {
+		t.Errorf("expected error %v, got %v", pkg.ErrInvalidUser, err)
+	}
+}
+
+func TestSqliteRepositoryUsers(t *testing.T) {
+	t.Run("progress", func(t *testing.T) {
+		testUsers(t, newSqliteRepository(t))
+	})
+
+	t.Run("reopen", func(t *testing.T) {
+		filename := path.Join(t.TempDir(), "database.db")
+
+		repository, err := pkg.NewSqliteRepository(filename)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Repetitions: 2})
+		repository.ForUser("alice")
+		repository.Close()
+
+		repository, err = pkg.NewSqliteRepository(filename)
+		if err != nil {
+			t.Fatalf("expected no error reopening, got %v", err)
+		}
+
+		defer repository.Close()
+
+		words, _ := repository.ListWords("german", nil, "", "", 0)
+		if len(words) != 1 || words[0].Repetitions != 2 {
+			t.Errorf("expected the default user to keep their progress, got %v", words)
+		}
+
+		alice, _ := repository.ForUser("alice")
+		if words, _ := alice.ListWords("german", nil, "", "", 0); len(words) != 1 || words[0].Repetitions != 0 {
+			t.Errorf("expected alice to find Haus new, got %v", words)
+		}
+	})
+}
+
+func TestSqliteRepositoryTokens(t *testing.T) {
+	repository := newSqliteRepository(t)
+
+	_, token, err := pkg.NewToken("alice", "dashboard", pkg.SCOPE_READ)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	added, err := repository.AddToken(token)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, other, _ := pkg.NewToken("bob", "", pkg.SCOPE_WRITE)
+	repository.AddToken(other)
+
+	found, err := repository.FindToken(token.Hash)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if found.ID != added.ID || found.User != "alice" || found.Scope != pkg.SCOPE_READ || found.Name != "dashboard" {
+		t.Errorf("expected read token of alice, got %v", found)
+	}
+
+	if tokens, _ := repository.ListTokens("alice"); len(tokens) != 1 {
+		t.Errorf("expected %d token, got %d", 1, len(tokens))
+	}
+
+	if tokens, _ := repository.ListTokens(""); len(tokens) != 2 {
+		t.Errorf("expected %d tokens, got %d", 2, len(tokens))
+	}
+
+	if err := repository.DeleteToken(added.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := repository.FindToken(token.Hash); err != pkg.ErrTokenNotFound {
+		t.Errorf("expected error %v, got %v", pkg.ErrTokenNotFound, err)
+	}
+
+	if err := repository.DeleteToken(added.ID); err != pkg.ErrTokenNotFound {
+		t.Errorf("expected error %v, got %v", pkg.ErrTokenNotFound, err)
+	}
+}
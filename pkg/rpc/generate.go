@@ -0,0 +1,5 @@
+// Package rpc holds the gRPC contract of gocab and the code generated from
+// it, served by pkg.GrpcServer
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative gocab.proto
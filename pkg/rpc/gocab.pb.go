@@ -0,0 +1,2409 @@
+// gRPC contract of gocab, mirroring the Service interface. Calls are made
+// for the user of the token sent in the "authorization" metadata, as
+// "Bearer <token>", when the server requires tokens.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        (unknown)
+// source: gocab.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type QuestionType int32
+
+const (
+	QuestionType_QUESTION_TYPE_UNSPECIFIED        QuestionType = 0
+	QuestionType_QUESTION_TYPE_FOREIGN_TO_ENGLISH QuestionType = 1
+	QuestionType_QUESTION_TYPE_ENGLISH_TO_FOREIGN QuestionType = 2
+	QuestionType_QUESTION_TYPE_MULTIPLE_CHOICE    QuestionType = 3
+	QuestionType_QUESTION_TYPE_CLOZE              QuestionType = 4
+	QuestionType_QUESTION_TYPE_ARTICLE            QuestionType = 5
+)
+
+// Enum value maps for QuestionType.
+var (
+	QuestionType_name = map[int32]string{
+		0: "QUESTION_TYPE_UNSPECIFIED",
+		1: "QUESTION_TYPE_FOREIGN_TO_ENGLISH",
+		2: "QUESTION_TYPE_ENGLISH_TO_FOREIGN",
+		3: "QUESTION_TYPE_MULTIPLE_CHOICE",
+		4: "QUESTION_TYPE_CLOZE",
+		5: "QUESTION_TYPE_ARTICLE",
+	}
+	QuestionType_value = map[string]int32{
+		"QUESTION_TYPE_UNSPECIFIED":        0,
+		"QUESTION_TYPE_FOREIGN_TO_ENGLISH": 1,
+		"QUESTION_TYPE_ENGLISH_TO_FOREIGN": 2,
+		"QUESTION_TYPE_MULTIPLE_CHOICE":    3,
+		"QUESTION_TYPE_CLOZE":              4,
+		"QUESTION_TYPE_ARTICLE":            5,
+	}
+)
+
+func (x QuestionType) Enum() *QuestionType {
+	p := new(QuestionType)
+	*p = x
+	return p
+}
+
+func (x QuestionType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QuestionType) Descriptor() protoreflect.EnumDescriptor {
+	return file_gocab_proto_enumTypes[0].Descriptor()
+}
+
+func (QuestionType) Type() protoreflect.EnumType {
+	return &file_gocab_proto_enumTypes[0]
+}
+
+func (x QuestionType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QuestionType.Descriptor instead.
+func (QuestionType) EnumDescriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{0}
+}
+
+type Result int32
+
+const (
+	Result_RESULT_UNSPECIFIED Result = 0
+	Result_RESULT_WRONG       Result = 1
+	// The right noun with the wrong article
+	Result_RESULT_WRONG_ARTICLE Result = 2
+	// A few typos away from the expected answer
+	Result_RESULT_ALMOST Result = 3
+	// Right once special letters are folded
+	Result_RESULT_NORMALIZED Result = 4
+	Result_RESULT_EXACT      Result = 5
+)
+
+// Enum value maps for Result.
+var (
+	Result_name = map[int32]string{
+		0: "RESULT_UNSPECIFIED",
+		1: "RESULT_WRONG",
+		2: "RESULT_WRONG_ARTICLE",
+		3: "RESULT_ALMOST",
+		4: "RESULT_NORMALIZED",
+		5: "RESULT_EXACT",
+	}
+	Result_value = map[string]int32{
+		"RESULT_UNSPECIFIED":   0,
+		"RESULT_WRONG":         1,
+		"RESULT_WRONG_ARTICLE": 2,
+		"RESULT_ALMOST":        3,
+		"RESULT_NORMALIZED":    4,
+		"RESULT_EXACT":         5,
+	}
+)
+
+func (x Result) Enum() *Result {
+	p := new(Result)
+	*p = x
+	return p
+}
+
+func (x Result) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Result) Descriptor() protoreflect.EnumDescriptor {
+	return file_gocab_proto_enumTypes[1].Descriptor()
+}
+
+func (Result) Type() protoreflect.EnumType {
+	return &file_gocab_proto_enumTypes[1]
+}
+
+func (x Result) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Result.Descriptor instead.
+func (Result) EnumDescriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{1}
+}
+
+type Meaning struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Note string `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+}
+
+func (x *Meaning) Reset() {
+	*x = Meaning{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Meaning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Meaning) ProtoMessage() {}
+
+func (x *Meaning) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Meaning.ProtoReflect.Descriptor instead.
+func (*Meaning) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Meaning) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Meaning) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type Word struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lang string `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	Word string `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
+	// Article nouns are used with, as "das" for "Haus"
+	Article string `protobuf:"bytes,3,opt,name=article,proto3" json:"article,omitempty"`
+	// Draft words were imported without a meaning, still to be filled in
+	Draft         bool                   `protobuf:"varint,4,opt,name=draft,proto3" json:"draft,omitempty"`
+	Meanings      []*Meaning             `protobuf:"bytes,5,rep,name=meanings,proto3" json:"meanings,omitempty"`
+	Pronunciation string                 `protobuf:"bytes,6,opt,name=pronunciation,proto3" json:"pronunciation,omitempty"`
+	Example       string                 `protobuf:"bytes,7,opt,name=example,proto3" json:"example,omitempty"`
+	Tags          []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	Added         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=added,proto3" json:"added,omitempty"`
+	Score         float64                `protobuf:"fixed64,10,opt,name=score,proto3" json:"score,omitempty"`
+	// Scheduling state of the user
+	Ease        float64                `protobuf:"fixed64,11,opt,name=ease,proto3" json:"ease,omitempty"`
+	Interval    int32                  `protobuf:"varint,12,opt,name=interval,proto3" json:"interval,omitempty"`
+	Repetitions int32                  `protobuf:"varint,13,opt,name=repetitions,proto3" json:"repetitions,omitempty"`
+	Due         *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=due,proto3" json:"due,omitempty"`
+	Reviewed    *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=reviewed,proto3" json:"reviewed,omitempty"`
+	Stability   float64                `protobuf:"fixed64,16,opt,name=stability,proto3" json:"stability,omitempty"`
+	Difficulty  float64                `protobuf:"fixed64,17,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Box         int32                  `protobuf:"varint,18,opt,name=box,proto3" json:"box,omitempty"`
+}
+
+func (x *Word) Reset() {
+	*x = Word{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Word) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Word) ProtoMessage() {}
+
+func (x *Word) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Word.ProtoReflect.Descriptor instead.
+func (*Word) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Word) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *Word) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *Word) GetArticle() string {
+	if x != nil {
+		return x.Article
+	}
+	return ""
+}
+
+func (x *Word) GetDraft() bool {
+	if x != nil {
+		return x.Draft
+	}
+	return false
+}
+
+func (x *Word) GetMeanings() []*Meaning {
+	if x != nil {
+		return x.Meanings
+	}
+	return nil
+}
+
+func (x *Word) GetPronunciation() string {
+	if x != nil {
+		return x.Pronunciation
+	}
+	return ""
+}
+
+func (x *Word) GetExample() string {
+	if x != nil {
+		return x.Example
+	}
+	return ""
+}
+
+func (x *Word) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Word) GetAdded() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *Word) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Word) GetEase() float64 {
+	if x != nil {
+		return x.Ease
+	}
+	return 0
+}
+
+func (x *Word) GetInterval() int32 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *Word) GetRepetitions() int32 {
+	if x != nil {
+		return x.Repetitions
+	}
+	return 0
+}
+
+func (x *Word) GetDue() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Due
+	}
+	return nil
+}
+
+func (x *Word) GetReviewed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Reviewed
+	}
+	return nil
+}
+
+func (x *Word) GetStability() float64 {
+	if x != nil {
+		return x.Stability
+	}
+	return 0
+}
+
+func (x *Word) GetDifficulty() float64 {
+	if x != nil {
+		return x.Difficulty
+	}
+	return 0
+}
+
+func (x *Word) GetBox() int32 {
+	if x != nil {
+		return x.Box
+	}
+	return 0
+}
+
+type AddWordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Word to add, written with or without its article
+	Word *Word `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+}
+
+func (x *AddWordRequest) Reset() {
+	*x = AddWordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddWordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWordRequest) ProtoMessage() {}
+
+func (x *AddWordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWordRequest.ProtoReflect.Descriptor instead.
+func (*AddWordRequest) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AddWordRequest) GetWord() *Word {
+	if x != nil {
+		return x.Word
+	}
+	return nil
+}
+
+type UpdateWordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Word *Word `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+}
+
+func (x *UpdateWordRequest) Reset() {
+	*x = UpdateWordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateWordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWordRequest) ProtoMessage() {}
+
+func (x *UpdateWordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWordRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWordRequest) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateWordRequest) GetWord() *Word {
+	if x != nil {
+		return x.Word
+	}
+	return nil
+}
+
+type HasWordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lang string `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	Word string `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
+}
+
+func (x *HasWordRequest) Reset() {
+	*x = HasWordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HasWordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HasWordRequest) ProtoMessage() {}
+
+func (x *HasWordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HasWordRequest.ProtoReflect.Descriptor instead.
+func (*HasWordRequest) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *HasWordRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *HasWordRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+type HasWordResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Exists bool `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
+}
+
+func (x *HasWordResponse) Reset() {
+	*x = HasWordResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HasWordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HasWordResponse) ProtoMessage() {}
+
+func (x *HasWordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HasWordResponse.ProtoReflect.Descriptor instead.
+func (*HasWordResponse) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HasWordResponse) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+type DeleteWordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lang string `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	Word string `protobuf:"bytes,2,opt,name=word,proto3" json:"word,omitempty"`
+}
+
+func (x *DeleteWordRequest) Reset() {
+	*x = DeleteWordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteWordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWordRequest) ProtoMessage() {}
+
+func (x *DeleteWordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWordRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWordRequest) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteWordRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *DeleteWordRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+type DeleteWordResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteWordResponse) Reset() {
+	*x = DeleteWordResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteWordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWordResponse) ProtoMessage() {}
+
+func (x *DeleteWordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWordResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWordResponse) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{7}
+}
+
+type ListWordsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lang string   `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	Tags []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	// hard, medium or easy, every level when empty
+	Level string `protobuf:"bytes,3,opt,name=level,proto3" json:"level,omitempty"`
+	// word, score or added, word when empty
+	Sort string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	// Maximum number of words, every word when zero
+	Limit int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListWordsRequest) Reset() {
+	*x = ListWordsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWordsRequest) ProtoMessage() {}
+
+func (x *ListWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWordsRequest.ProtoReflect.Descriptor instead.
+func (*ListWordsRequest) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListWordsRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *ListWordsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListWordsRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *ListWordsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListWordsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWordsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Words []*Word `protobuf:"bytes,1,rep,name=words,proto3" json:"words,omitempty"`
+}
+
+func (x *ListWordsResponse) Reset() {
+	*x = ListWordsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListWordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWordsResponse) ProtoMessage() {}
+
+func (x *ListWordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWordsResponse.ProtoReflect.Descriptor instead.
+func (*ListWordsResponse) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListWordsResponse) GetWords() []*Word {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+type SearchWordsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Language to search, every language when empty
+	Lang  string `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *SearchWordsRequest) Reset() {
+	*x = SearchWordsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchWordsRequest) ProtoMessage() {}
+
+func (x *SearchWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchWordsRequest.ProtoReflect.Descriptor instead.
+func (*SearchWordsRequest) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchWordsRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *SearchWordsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Word *Word `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	// Fields matching the query: word, meaning, pronunciation or example
+	Fields []string `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchResult) GetWord() *Word {
+	if x != nil {
+		return x.Word
+	}
+	return nil
+}
+
+func (x *SearchResult) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type SearchWordsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*SearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *SearchWordsResponse) Reset() {
+	*x = SearchWordsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchWordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchWordsResponse) ProtoMessage() {}
+
+func (x *SearchWordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchWordsResponse.ProtoReflect.Descriptor instead.
+func (*SearchWordsResponse) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchWordsResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ImportWordsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Words []*Word `protobuf:"bytes,1,rep,name=words,proto3" json:"words,omitempty"`
+}
+
+func (x *ImportWordsRequest) Reset() {
+	*x = ImportWordsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportWordsRequest) ProtoMessage() {}
+
+func (x *ImportWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportWordsRequest.ProtoReflect.Descriptor instead.
+func (*ImportWordsRequest) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ImportWordsRequest) GetWords() []*Word {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+type ImportWordsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Imported int32 `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	// Errors of the words that could not be imported
+	Rejected map[string]string `protobuf:"bytes,2,rep,name=rejected,proto3" json:"rejected,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ImportWordsResponse) Reset() {
+	*x = ImportWordsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportWordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportWordsResponse) ProtoMessage() {}
+
+func (x *ImportWordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportWordsResponse.ProtoReflect.Descriptor instead.
+func (*ImportWordsResponse) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ImportWordsResponse) GetImported() int32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportWordsResponse) GetRejected() map[string]string {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+type GetSettingsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lang string `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+}
+
+func (x *GetSettingsRequest) Reset() {
+	*x = GetSettingsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSettingsRequest) ProtoMessage() {}
+
+func (x *GetSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetSettingsRequest) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+type Settings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lang string `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	// sm2, fsrs or leitner
+	Scheduler string  `protobuf:"bytes,2,opt,name=scheduler,proto3" json:"scheduler,omitempty"`
+	Retention float64 `protobuf:"fixed64,3,opt,name=retention,proto3" json:"retention,omitempty"`
+	// Leitner boxes and the days between reviews of each box
+	Boxes        int32   `protobuf:"varint,4,opt,name=boxes,proto3" json:"boxes,omitempty"`
+	BoxIntervals []int32 `protobuf:"varint,5,rep,packed,name=box_intervals,json=boxIntervals,proto3" json:"box_intervals,omitempty"`
+}
+
+func (x *Settings) Reset() {
+	*x = Settings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Settings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Settings) ProtoMessage() {}
+
+func (x *Settings) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Settings.ProtoReflect.Descriptor instead.
+func (*Settings) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Settings) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *Settings) GetScheduler() string {
+	if x != nil {
+		return x.Scheduler
+	}
+	return ""
+}
+
+func (x *Settings) GetRetention() float64 {
+	if x != nil {
+		return x.Retention
+	}
+	return 0
+}
+
+func (x *Settings) GetBoxes() int32 {
+	if x != nil {
+		return x.Boxes
+	}
+	return 0
+}
+
+func (x *Settings) GetBoxIntervals() []int32 {
+	if x != nil {
+		return x.BoxIntervals
+	}
+	return nil
+}
+
+type QuizOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lang string   `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+	Tags []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Overrides the language's scheduler for this quiz
+	Scheduler string `protobuf:"bytes,3,opt,name=scheduler,proto3" json:"scheduler,omitempty"`
+	// Only accepts answers typed with the language's special letters
+	Strict bool `protobuf:"varint,4,opt,name=strict,proto3" json:"strict,omitempty"`
+	// ignore, require or ask, whether nouns must be answered with their
+	// article or their article is asked separately
+	Articles string `protobuf:"bytes,5,opt,name=articles,proto3" json:"articles,omitempty"`
+	// Number of wrong choices offered along the right meaning, zero asking
+	// to type the answers instead
+	Choices int32 `protobuf:"varint,6,opt,name=choices,proto3" json:"choices,omitempty"`
+}
+
+func (x *QuizOptions) Reset() {
+	*x = QuizOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuizOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuizOptions) ProtoMessage() {}
+
+func (x *QuizOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuizOptions.ProtoReflect.Descriptor instead.
+func (*QuizOptions) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *QuizOptions) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *QuizOptions) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *QuizOptions) GetScheduler() string {
+	if x != nil {
+		return x.Scheduler
+	}
+	return ""
+}
+
+func (x *QuizOptions) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
+func (x *QuizOptions) GetArticles() string {
+	if x != nil {
+		return x.Articles
+	}
+	return ""
+}
+
+func (x *QuizOptions) GetChoices() int32 {
+	if x != nil {
+		return x.Choices
+	}
+	return 0
+}
+
+type Answer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Typed answer, or the position of the picked choice starting at 1
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *Answer) Reset() {
+	*x = Answer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Answer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Answer) ProtoMessage() {}
+
+func (x *Answer) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Answer.ProtoReflect.Descriptor instead.
+func (*Answer) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Answer) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type TakeQuizRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Request:
+	//	*TakeQuizRequest_Start
+	//	*TakeQuizRequest_Answer
+	Request isTakeQuizRequest_Request `protobuf_oneof:"request"`
+}
+
+func (x *TakeQuizRequest) Reset() {
+	*x = TakeQuizRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TakeQuizRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TakeQuizRequest) ProtoMessage() {}
+
+func (x *TakeQuizRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TakeQuizRequest.ProtoReflect.Descriptor instead.
+func (*TakeQuizRequest) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{19}
+}
+
+func (m *TakeQuizRequest) GetRequest() isTakeQuizRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *TakeQuizRequest) GetStart() *QuizOptions {
+	if x, ok := x.GetRequest().(*TakeQuizRequest_Start); ok {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *TakeQuizRequest) GetAnswer() *Answer {
+	if x, ok := x.GetRequest().(*TakeQuizRequest_Answer); ok {
+		return x.Answer
+	}
+	return nil
+}
+
+type isTakeQuizRequest_Request interface {
+	isTakeQuizRequest_Request()
+}
+
+type TakeQuizRequest_Start struct {
+	Start *QuizOptions `protobuf:"bytes,1,opt,name=start,proto3,oneof"`
+}
+
+type TakeQuizRequest_Answer struct {
+	Answer *Answer `protobuf:"bytes,2,opt,name=answer,proto3,oneof"`
+}
+
+func (*TakeQuizRequest_Start) isTakeQuizRequest_Request() {}
+
+func (*TakeQuizRequest_Answer) isTakeQuizRequest_Request() {}
+
+type Question struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Position of the question in the quiz, starting at 0
+	Id      int32        `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Total   int32        `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Type    QuestionType `protobuf:"varint,3,opt,name=type,proto3,enum=gocab.QuestionType" json:"type,omitempty"`
+	Level   string       `protobuf:"bytes,4,opt,name=level,proto3" json:"level,omitempty"`
+	Text    string       `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	Choices []string     `protobuf:"bytes,6,rep,name=choices,proto3" json:"choices,omitempty"`
+}
+
+func (x *Question) Reset() {
+	*x = Question{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Question) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Question) ProtoMessage() {}
+
+func (x *Question) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Question.ProtoReflect.Descriptor instead.
+func (*Question) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Question) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Question) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Question) GetType() QuestionType {
+	if x != nil {
+		return x.Type
+	}
+	return QuestionType_QUESTION_TYPE_UNSPECIFIED
+}
+
+func (x *Question) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *Question) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Question) GetChoices() []string {
+	if x != nil {
+		return x.Choices
+	}
+	return nil
+}
+
+type Grade struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result        Result `protobuf:"varint,1,opt,name=result,proto3,enum=gocab.Result" json:"result,omitempty"`
+	Correct       bool   `protobuf:"varint,2,opt,name=correct,proto3" json:"correct,omitempty"`
+	Expected      string `protobuf:"bytes,3,opt,name=expected,proto3" json:"expected,omitempty"`
+	Pronunciation string `protobuf:"bytes,4,opt,name=pronunciation,proto3" json:"pronunciation,omitempty"`
+}
+
+func (x *Grade) Reset() {
+	*x = Grade{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Grade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Grade) ProtoMessage() {}
+
+func (x *Grade) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Grade.ProtoReflect.Descriptor instead.
+func (*Grade) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Grade) GetResult() Result {
+	if x != nil {
+		return x.Result
+	}
+	return Result_RESULT_UNSPECIFIED
+}
+
+func (x *Grade) GetCorrect() bool {
+	if x != nil {
+		return x.Correct
+	}
+	return false
+}
+
+func (x *Grade) GetExpected() string {
+	if x != nil {
+		return x.Expected
+	}
+	return ""
+}
+
+func (x *Grade) GetPronunciation() string {
+	if x != nil {
+		return x.Pronunciation
+	}
+	return ""
+}
+
+type Summary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total         int32  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Correct       int32  `protobuf:"varint,2,opt,name=correct,proto3" json:"correct,omitempty"`
+	NearMisses    int32  `protobuf:"varint,3,opt,name=near_misses,json=nearMisses,proto3" json:"near_misses,omitempty"`
+	WrongArticles int32  `protobuf:"varint,4,opt,name=wrong_articles,json=wrongArticles,proto3" json:"wrong_articles,omitempty"`
+	Mistakes      int32  `protobuf:"varint,5,opt,name=mistakes,proto3" json:"mistakes,omitempty"`
+	Text          string `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *Summary) Reset() {
+	*x = Summary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Summary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Summary) ProtoMessage() {}
+
+func (x *Summary) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Summary.ProtoReflect.Descriptor instead.
+func (*Summary) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Summary) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Summary) GetCorrect() int32 {
+	if x != nil {
+		return x.Correct
+	}
+	return 0
+}
+
+func (x *Summary) GetNearMisses() int32 {
+	if x != nil {
+		return x.NearMisses
+	}
+	return 0
+}
+
+func (x *Summary) GetWrongArticles() int32 {
+	if x != nil {
+		return x.WrongArticles
+	}
+	return 0
+}
+
+func (x *Summary) GetMistakes() int32 {
+	if x != nil {
+		return x.Mistakes
+	}
+	return 0
+}
+
+func (x *Summary) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type TakeQuizResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Response:
+	//	*TakeQuizResponse_Question
+	//	*TakeQuizResponse_Grade
+	//	*TakeQuizResponse_Summary
+	Response isTakeQuizResponse_Response `protobuf_oneof:"response"`
+}
+
+func (x *TakeQuizResponse) Reset() {
+	*x = TakeQuizResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gocab_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TakeQuizResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TakeQuizResponse) ProtoMessage() {}
+
+func (x *TakeQuizResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gocab_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TakeQuizResponse.ProtoReflect.Descriptor instead.
+func (*TakeQuizResponse) Descriptor() ([]byte, []int) {
+	return file_gocab_proto_rawDescGZIP(), []int{23}
+}
+
+func (m *TakeQuizResponse) GetResponse() isTakeQuizResponse_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (x *TakeQuizResponse) GetQuestion() *Question {
+	if x, ok := x.GetResponse().(*TakeQuizResponse_Question); ok {
+		return x.Question
+	}
+	return nil
+}
+
+func (x *TakeQuizResponse) GetGrade() *Grade {
+	if x, ok := x.GetResponse().(*TakeQuizResponse_Grade); ok {
+		return x.Grade
+	}
+	return nil
+}
+
+func (x *TakeQuizResponse) GetSummary() *Summary {
+	if x, ok := x.GetResponse().(*TakeQuizResponse_Summary); ok {
+		return x.Summary
+	}
+	return nil
+}
+
+type isTakeQuizResponse_Response interface {
+	isTakeQuizResponse_Response()
+}
+
+type TakeQuizResponse_Question struct {
+	Question *Question `protobuf:"bytes,1,opt,name=question,proto3,oneof"`
+}
+
+type TakeQuizResponse_Grade struct {
+	Grade *Grade `protobuf:"bytes,2,opt,name=grade,proto3,oneof"`
+}
+
+type TakeQuizResponse_Summary struct {
+	Summary *Summary `protobuf:"bytes,3,opt,name=summary,proto3,oneof"`
+}
+
+func (*TakeQuizResponse_Question) isTakeQuizResponse_Response() {}
+
+func (*TakeQuizResponse_Grade) isTakeQuizResponse_Response() {}
+
+func (*TakeQuizResponse_Summary) isTakeQuizResponse_Response() {}
+
+var File_gocab_proto protoreflect.FileDescriptor
+
+var file_gocab_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x67,
+	0x6f, 0x63, 0x61, 0x62, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x31, 0x0a, 0x07, 0x4d, 0x65, 0x61, 0x6e, 0x69, 0x6e, 0x67,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x22, 0xae, 0x04, 0x0a, 0x04, 0x57, 0x6f, 0x72,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6c, 0x61, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x72, 0x74,
+	0x69, 0x63, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x72, 0x74, 0x69,
+	0x63, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x72, 0x61, 0x66, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x64, 0x72, 0x61, 0x66, 0x74, 0x12, 0x2a, 0x0a, 0x08, 0x6d, 0x65, 0x61,
+	0x6e, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x67, 0x6f,
+	0x63, 0x61, 0x62, 0x2e, 0x4d, 0x65, 0x61, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x6d, 0x65, 0x61,
+	0x6e, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x72, 0x6f, 0x6e, 0x75, 0x6e, 0x63,
+	0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72,
+	0x6f, 0x6e, 0x75, 0x6e, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x30, 0x0a, 0x05, 0x61, 0x64, 0x64,
+	0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x61, 0x73, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x04, 0x65, 0x61, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x65, 0x74, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x2c, 0x0a, 0x03, 0x64, 0x75, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x03, 0x64, 0x75,
+	0x65, 0x12, 0x36, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x64, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x08, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x10, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x69, 0x66, 0x66, 0x69,
+	0x63, 0x75, 0x6c, 0x74, 0x79, 0x18, 0x11, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x64, 0x69, 0x66,
+	0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x6f, 0x78, 0x18, 0x12,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x62, 0x6f, 0x78, 0x22, 0x31, 0x0a, 0x0e, 0x41, 0x64, 0x64,
+	0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x04, 0x77,
+	0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x6f, 0x63, 0x61,
+	0x62, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x34, 0x0a, 0x11,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1f, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x04, 0x77, 0x6f,
+	0x72, 0x64, 0x22, 0x38, 0x0a, 0x0e, 0x48, 0x61, 0x73, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6c, 0x61, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x29, 0x0a, 0x0f,
+	0x48, 0x61, 0x73, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x22, 0x3b, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6c, 0x61, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x61, 0x6e, 0x67,
+	0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x77, 0x6f, 0x72, 0x64, 0x22, 0x14, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x6f,
+	0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x7a, 0x0a, 0x10, 0x4c, 0x69,
+	0x73, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6c, 0x61, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x61,
+	0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x36, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x6f,
+	0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x77,
+	0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x6f, 0x63,
+	0x61, 0x62, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x3e,
+	0x0a, 0x12, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6c, 0x61, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0x47,
+	0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1f,
+	0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67,
+	0x6f, 0x63, 0x61, 0x62, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x44, 0x0a, 0x13, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d,
+	0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x37, 0x0a,
+	0x12, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x52,
+	0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x22, 0xb4, 0x01, 0x0a, 0x13, 0x49, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x44, 0x0a, 0x08, 0x72, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x67,
+	0x6f, 0x63, 0x61, 0x62, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x1a, 0x3b, 0x0a, 0x0d, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x28, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6c, 0x61, 0x6e, 0x67, 0x22, 0x95, 0x01, 0x0a, 0x08, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6c, 0x61, 0x6e, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x72, 0x65, 0x74, 0x65, 0x6e,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6f, 0x78, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x62, 0x6f, 0x78, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x6f,
+	0x78, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x05, 0x52, 0x0c, 0x62, 0x6f, 0x78, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x73, 0x22,
+	0xa1, 0x01, 0x0a, 0x0b, 0x51, 0x75, 0x69, 0x7a, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x6c, 0x61, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c,
+	0x61, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x6f,
+	0x69, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x63, 0x68, 0x6f, 0x69,
+	0x63, 0x65, 0x73, 0x22, 0x1c, 0x0a, 0x06, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78,
+	0x74, 0x22, 0x71, 0x0a, 0x0f, 0x54, 0x61, 0x6b, 0x65, 0x51, 0x75, 0x69, 0x7a, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x51, 0x75, 0x69, 0x7a,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x48, 0x00, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x12, 0x27, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48,
+	0x00, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x9d, 0x01, 0x0a, 0x08, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x27, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x51, 0x75,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68,
+	0x6f, 0x69, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x6f,
+	0x69, 0x63, 0x65, 0x73, 0x22, 0x8a, 0x01, 0x0a, 0x05, 0x47, 0x72, 0x61, 0x64, 0x65, 0x12, 0x25,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0d,
+	0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x12,
+	0x1a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x70,
+	0x72, 0x6f, 0x6e, 0x75, 0x6e, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x6e, 0x75, 0x6e, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0xb1, 0x01, 0x0a, 0x07, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6e, 0x65, 0x61, 0x72, 0x5f, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0a, 0x6e, 0x65, 0x61, 0x72, 0x4d, 0x69, 0x73, 0x73, 0x65, 0x73, 0x12, 0x25,
+	0x0a, 0x0e, 0x77, 0x72, 0x6f, 0x6e, 0x67, 0x5f, 0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x77, 0x72, 0x6f, 0x6e, 0x67, 0x41, 0x72, 0x74,
+	0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x69, 0x73, 0x74, 0x61, 0x6b, 0x65,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x69, 0x73, 0x74, 0x61, 0x6b, 0x65,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x22, 0x9f, 0x01, 0x0a, 0x10, 0x54, 0x61, 0x6b, 0x65, 0x51, 0x75,
+	0x69, 0x7a, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x08, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67,
+	0x6f, 0x63, 0x61, 0x62, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
+	0x08, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x05, 0x67, 0x72, 0x61,
+	0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62,
+	0x2e, 0x47, 0x72, 0x61, 0x64, 0x65, 0x48, 0x00, 0x52, 0x05, 0x67, 0x72, 0x61, 0x64, 0x65, 0x12,
+	0x2a, 0x0a, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x42, 0x0a, 0x0a, 0x08, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0xd0, 0x01, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x19, 0x51, 0x55, 0x45, 0x53,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x24, 0x0a, 0x20, 0x51, 0x55, 0x45, 0x53, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x46, 0x4f, 0x52, 0x45, 0x49, 0x47, 0x4e,
+	0x5f, 0x54, 0x4f, 0x5f, 0x45, 0x4e, 0x47, 0x4c, 0x49, 0x53, 0x48, 0x10, 0x01, 0x12, 0x24, 0x0a,
+	0x20, 0x51, 0x55, 0x45, 0x53, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x45,
+	0x4e, 0x47, 0x4c, 0x49, 0x53, 0x48, 0x5f, 0x54, 0x4f, 0x5f, 0x46, 0x4f, 0x52, 0x45, 0x49, 0x47,
+	0x4e, 0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x51, 0x55, 0x45, 0x53, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x50, 0x4c, 0x45, 0x5f, 0x43, 0x48,
+	0x4f, 0x49, 0x43, 0x45, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x51, 0x55, 0x45, 0x53, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4c, 0x4f, 0x5a, 0x45, 0x10, 0x04, 0x12,
+	0x19, 0x0a, 0x15, 0x51, 0x55, 0x45, 0x53, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x41, 0x52, 0x54, 0x49, 0x43, 0x4c, 0x45, 0x10, 0x05, 0x2a, 0x88, 0x01, 0x0a, 0x06, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x12, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a,
+	0x0c, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x57, 0x52, 0x4f, 0x4e, 0x47, 0x10, 0x01, 0x12,
+	0x18, 0x0a, 0x14, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x57, 0x52, 0x4f, 0x4e, 0x47, 0x5f,
+	0x41, 0x52, 0x54, 0x49, 0x43, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x45, 0x53,
+	0x55, 0x4c, 0x54, 0x5f, 0x41, 0x4c, 0x4d, 0x4f, 0x53, 0x54, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11,
+	0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x4e, 0x4f, 0x52, 0x4d, 0x41, 0x4c, 0x49, 0x5a, 0x45,
+	0x44, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x45, 0x58,
+	0x41, 0x43, 0x54, 0x10, 0x05, 0x32, 0xe2, 0x04, 0x0a, 0x05, 0x47, 0x6f, 0x63, 0x61, 0x62, 0x12,
+	0x2d, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x15, 0x2e, 0x67, 0x6f, 0x63,
+	0x61, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x0b, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x33,
+	0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x18, 0x2e, 0x67,
+	0x6f, 0x63, 0x61, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x57,
+	0x6f, 0x72, 0x64, 0x12, 0x38, 0x0a, 0x07, 0x48, 0x61, 0x73, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x15,
+	0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x48, 0x61, 0x73, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x48, 0x61,
+	0x73, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a,
+	0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x18, 0x2e, 0x67, 0x6f,
+	0x63, 0x61, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x57, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3e, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x17, 0x2e,
+	0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x44, 0x0a, 0x0b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x12,
+	0x19, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x57, 0x6f,
+	0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x6f, 0x63,
+	0x61, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74,
+	0x57, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x19, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x49, 0x6d,
+	0x70, 0x6f, 0x72, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x57,
+	0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0b,
+	0x47, 0x65, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x19, 0x2e, 0x67, 0x6f,
+	0x63, 0x61, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x30, 0x0a, 0x0c, 0x53, 0x61, 0x76, 0x65, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x0f, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x1a, 0x0f, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62,
+	0x2e, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x3f, 0x0a, 0x08, 0x54, 0x61, 0x6b,
+	0x65, 0x51, 0x75, 0x69, 0x7a, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x54, 0x61,
+	0x6b, 0x65, 0x51, 0x75, 0x69, 0x7a, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x67, 0x6f, 0x63, 0x61, 0x62, 0x2e, 0x54, 0x61, 0x6b, 0x65, 0x51, 0x75, 0x69, 0x7a, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x1b, 0x5a, 0x19, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x63, 0x61, 0x62, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gocab_proto_rawDescOnce sync.Once
+	file_gocab_proto_rawDescData = file_gocab_proto_rawDesc
+)
+
+func file_gocab_proto_rawDescGZIP() []byte {
+	file_gocab_proto_rawDescOnce.Do(func() {
+		file_gocab_proto_rawDescData = protoimpl.X.CompressGZIP(file_gocab_proto_rawDescData)
+	})
+	return file_gocab_proto_rawDescData
+}
+
+var file_gocab_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_gocab_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_gocab_proto_goTypes = []interface{}{
+	(QuestionType)(0),             // 0: gocab.QuestionType
+	(Result)(0),                   // 1: gocab.Result
+	(*Meaning)(nil),               // 2: gocab.Meaning
+	(*Word)(nil),                  // 3: gocab.Word
+	(*AddWordRequest)(nil),        // 4: gocab.AddWordRequest
+	(*UpdateWordRequest)(nil),     // 5: gocab.UpdateWordRequest
+	(*HasWordRequest)(nil),        // 6: gocab.HasWordRequest
+	(*HasWordResponse)(nil),       // 7: gocab.HasWordResponse
+	(*DeleteWordRequest)(nil),     // 8: gocab.DeleteWordRequest
+	(*DeleteWordResponse)(nil),    // 9: gocab.DeleteWordResponse
+	(*ListWordsRequest)(nil),      // 10: gocab.ListWordsRequest
+	(*ListWordsResponse)(nil),     // 11: gocab.ListWordsResponse
+	(*SearchWordsRequest)(nil),    // 12: gocab.SearchWordsRequest
+	(*SearchResult)(nil),          // 13: gocab.SearchResult
+	(*SearchWordsResponse)(nil),   // 14: gocab.SearchWordsResponse
+	(*ImportWordsRequest)(nil),    // 15: gocab.ImportWordsRequest
+	(*ImportWordsResponse)(nil),   // 16: gocab.ImportWordsResponse
+	(*GetSettingsRequest)(nil),    // 17: gocab.GetSettingsRequest
+	(*Settings)(nil),              // 18: gocab.Settings
+	(*QuizOptions)(nil),           // 19: gocab.QuizOptions
+	(*Answer)(nil),                // 20: gocab.Answer
+	(*TakeQuizRequest)(nil),       // 21: gocab.TakeQuizRequest
+	(*Question)(nil),              // 22: gocab.Question
+	(*Grade)(nil),                 // 23: gocab.Grade
+	(*Summary)(nil),               // 24: gocab.Summary
+	(*TakeQuizResponse)(nil),      // 25: gocab.TakeQuizResponse
+	nil,                           // 26: gocab.ImportWordsResponse.RejectedEntry
+	(*timestamppb.Timestamp)(nil), // 27: google.protobuf.Timestamp
+}
+var file_gocab_proto_depIdxs = []int32{
+	2,  // 0: gocab.Word.meanings:type_name -> gocab.Meaning
+	27, // 1: gocab.Word.added:type_name -> google.protobuf.Timestamp
+	27, // 2: gocab.Word.due:type_name -> google.protobuf.Timestamp
+	27, // 3: gocab.Word.reviewed:type_name -> google.protobuf.Timestamp
+	3,  // 4: gocab.AddWordRequest.word:type_name -> gocab.Word
+	3,  // 5: gocab.UpdateWordRequest.word:type_name -> gocab.Word
+	3,  // 6: gocab.ListWordsResponse.words:type_name -> gocab.Word
+	3,  // 7: gocab.SearchResult.word:type_name -> gocab.Word
+	13, // 8: gocab.SearchWordsResponse.results:type_name -> gocab.SearchResult
+	3,  // 9: gocab.ImportWordsRequest.words:type_name -> gocab.Word
+	26, // 10: gocab.ImportWordsResponse.rejected:type_name -> gocab.ImportWordsResponse.RejectedEntry
+	19, // 11: gocab.TakeQuizRequest.start:type_name -> gocab.QuizOptions
+	20, // 12: gocab.TakeQuizRequest.answer:type_name -> gocab.Answer
+	0,  // 13: gocab.Question.type:type_name -> gocab.QuestionType
+	1,  // 14: gocab.Grade.result:type_name -> gocab.Result
+	22, // 15: gocab.TakeQuizResponse.question:type_name -> gocab.Question
+	23, // 16: gocab.TakeQuizResponse.grade:type_name -> gocab.Grade
+	24, // 17: gocab.TakeQuizResponse.summary:type_name -> gocab.Summary
+	4,  // 18: gocab.Gocab.AddWord:input_type -> gocab.AddWordRequest
+	5,  // 19: gocab.Gocab.UpdateWord:input_type -> gocab.UpdateWordRequest
+	6,  // 20: gocab.Gocab.HasWord:input_type -> gocab.HasWordRequest
+	8,  // 21: gocab.Gocab.DeleteWord:input_type -> gocab.DeleteWordRequest
+	10, // 22: gocab.Gocab.ListWords:input_type -> gocab.ListWordsRequest
+	12, // 23: gocab.Gocab.SearchWords:input_type -> gocab.SearchWordsRequest
+	15, // 24: gocab.Gocab.ImportWords:input_type -> gocab.ImportWordsRequest
+	17, // 25: gocab.Gocab.GetSettings:input_type -> gocab.GetSettingsRequest
+	18, // 26: gocab.Gocab.SaveSettings:input_type -> gocab.Settings
+	21, // 27: gocab.Gocab.TakeQuiz:input_type -> gocab.TakeQuizRequest
+	3,  // 28: gocab.Gocab.AddWord:output_type -> gocab.Word
+	3,  // 29: gocab.Gocab.UpdateWord:output_type -> gocab.Word
+	7,  // 30: gocab.Gocab.HasWord:output_type -> gocab.HasWordResponse
+	9,  // 31: gocab.Gocab.DeleteWord:output_type -> gocab.DeleteWordResponse
+	11, // 32: gocab.Gocab.ListWords:output_type -> gocab.ListWordsResponse
+	14, // 33: gocab.Gocab.SearchWords:output_type -> gocab.SearchWordsResponse
+	16, // 34: gocab.Gocab.ImportWords:output_type -> gocab.ImportWordsResponse
+	18, // 35: gocab.Gocab.GetSettings:output_type -> gocab.Settings
+	18, // 36: gocab.Gocab.SaveSettings:output_type -> gocab.Settings
+	25, // 37: gocab.Gocab.TakeQuiz:output_type -> gocab.TakeQuizResponse
+	28, // [28:38] is the sub-list for method output_type
+	18, // [18:28] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_gocab_proto_init() }
+func file_gocab_proto_init() {
+	if File_gocab_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gocab_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Meaning); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Word); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddWordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateWordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HasWordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HasWordResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteWordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteWordResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListWordsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListWordsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchWordsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchWordsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportWordsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportWordsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSettingsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Settings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuizOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Answer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TakeQuizRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Question); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Grade); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Summary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gocab_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TakeQuizResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_gocab_proto_msgTypes[19].OneofWrappers = []interface{}{
+		(*TakeQuizRequest_Start)(nil),
+		(*TakeQuizRequest_Answer)(nil),
+	}
+	file_gocab_proto_msgTypes[23].OneofWrappers = []interface{}{
+		(*TakeQuizResponse_Question)(nil),
+		(*TakeQuizResponse_Grade)(nil),
+		(*TakeQuizResponse_Summary)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gocab_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_gocab_proto_goTypes,
+		DependencyIndexes: file_gocab_proto_depIdxs,
+		EnumInfos:         file_gocab_proto_enumTypes,
+		MessageInfos:      file_gocab_proto_msgTypes,
+	}.Build()
+	File_gocab_proto = out.File
+	file_gocab_proto_rawDesc = nil
+	file_gocab_proto_goTypes = nil
+	file_gocab_proto_depIdxs = nil
+}
@@ -0,0 +1,491 @@
+// gRPC contract of gocab, mirroring the Service interface. Calls are made
+// for the user of the token sent in the "authorization" metadata, as
+// "Bearer <token>", when the server requires tokens.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: gocab.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Gocab_AddWord_FullMethodName      = "/gocab.Gocab/AddWord"
+	Gocab_UpdateWord_FullMethodName   = "/gocab.Gocab/UpdateWord"
+	Gocab_HasWord_FullMethodName      = "/gocab.Gocab/HasWord"
+	Gocab_DeleteWord_FullMethodName   = "/gocab.Gocab/DeleteWord"
+	Gocab_ListWords_FullMethodName    = "/gocab.Gocab/ListWords"
+	Gocab_SearchWords_FullMethodName  = "/gocab.Gocab/SearchWords"
+	Gocab_ImportWords_FullMethodName  = "/gocab.Gocab/ImportWords"
+	Gocab_GetSettings_FullMethodName  = "/gocab.Gocab/GetSettings"
+	Gocab_SaveSettings_FullMethodName = "/gocab.Gocab/SaveSettings"
+	Gocab_TakeQuiz_FullMethodName     = "/gocab.Gocab/TakeQuiz"
+)
+
+// GocabClient is the client API for Gocab service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GocabClient interface {
+	AddWord(ctx context.Context, in *AddWordRequest, opts ...grpc.CallOption) (*Word, error)
+	// UpdateWord replaces the definition of a word, keeping its progress
+	UpdateWord(ctx context.Context, in *UpdateWordRequest, opts ...grpc.CallOption) (*Word, error)
+	HasWord(ctx context.Context, in *HasWordRequest, opts ...grpc.CallOption) (*HasWordResponse, error)
+	DeleteWord(ctx context.Context, in *DeleteWordRequest, opts ...grpc.CallOption) (*DeleteWordResponse, error)
+	ListWords(ctx context.Context, in *ListWordsRequest, opts ...grpc.CallOption) (*ListWordsResponse, error)
+	SearchWords(ctx context.Context, in *SearchWordsRequest, opts ...grpc.CallOption) (*SearchWordsResponse, error)
+	// ImportWords adds new words and updates known ones
+	ImportWords(ctx context.Context, in *ImportWordsRequest, opts ...grpc.CallOption) (*ImportWordsResponse, error)
+	GetSettings(ctx context.Context, in *GetSettingsRequest, opts ...grpc.CallOption) (*Settings, error)
+	SaveSettings(ctx context.Context, in *Settings, opts ...grpc.CallOption) (*Settings, error)
+	// TakeQuiz starts a quiz with the options sent first, then streams a
+	// question for every answer sent, each answer being graded and saved
+	// right away. The summary follows the grade of the last answer and ends
+	// the stream.
+	TakeQuiz(ctx context.Context, opts ...grpc.CallOption) (Gocab_TakeQuizClient, error)
+}
+
+type gocabClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGocabClient(cc grpc.ClientConnInterface) GocabClient {
+	return &gocabClient{cc}
+}
+
+func (c *gocabClient) AddWord(ctx context.Context, in *AddWordRequest, opts ...grpc.CallOption) (*Word, error) {
+	out := new(Word)
+	err := c.cc.Invoke(ctx, Gocab_AddWord_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gocabClient) UpdateWord(ctx context.Context, in *UpdateWordRequest, opts ...grpc.CallOption) (*Word, error) {
+	out := new(Word)
+	err := c.cc.Invoke(ctx, Gocab_UpdateWord_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gocabClient) HasWord(ctx context.Context, in *HasWordRequest, opts ...grpc.CallOption) (*HasWordResponse, error) {
+	out := new(HasWordResponse)
+	err := c.cc.Invoke(ctx, Gocab_HasWord_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gocabClient) DeleteWord(ctx context.Context, in *DeleteWordRequest, opts ...grpc.CallOption) (*DeleteWordResponse, error) {
+	out := new(DeleteWordResponse)
+	err := c.cc.Invoke(ctx, Gocab_DeleteWord_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gocabClient) ListWords(ctx context.Context, in *ListWordsRequest, opts ...grpc.CallOption) (*ListWordsResponse, error) {
+	out := new(ListWordsResponse)
+	err := c.cc.Invoke(ctx, Gocab_ListWords_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gocabClient) SearchWords(ctx context.Context, in *SearchWordsRequest, opts ...grpc.CallOption) (*SearchWordsResponse, error) {
+	out := new(SearchWordsResponse)
+	err := c.cc.Invoke(ctx, Gocab_SearchWords_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gocabClient) ImportWords(ctx context.Context, in *ImportWordsRequest, opts ...grpc.CallOption) (*ImportWordsResponse, error) {
+	out := new(ImportWordsResponse)
+	err := c.cc.Invoke(ctx, Gocab_ImportWords_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gocabClient) GetSettings(ctx context.Context, in *GetSettingsRequest, opts ...grpc.CallOption) (*Settings, error) {
+	out := new(Settings)
+	err := c.cc.Invoke(ctx, Gocab_GetSettings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gocabClient) SaveSettings(ctx context.Context, in *Settings, opts ...grpc.CallOption) (*Settings, error) {
+	out := new(Settings)
+	err := c.cc.Invoke(ctx, Gocab_SaveSettings_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gocabClient) TakeQuiz(ctx context.Context, opts ...grpc.CallOption) (Gocab_TakeQuizClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Gocab_ServiceDesc.Streams[0], Gocab_TakeQuiz_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gocabTakeQuizClient{stream}
+	return x, nil
+}
+
+type Gocab_TakeQuizClient interface {
+	Send(*TakeQuizRequest) error
+	Recv() (*TakeQuizResponse, error)
+	grpc.ClientStream
+}
+
+type gocabTakeQuizClient struct {
+	grpc.ClientStream
+}
+
+func (x *gocabTakeQuizClient) Send(m *TakeQuizRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *gocabTakeQuizClient) Recv() (*TakeQuizResponse, error) {
+	m := new(TakeQuizResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GocabServer is the server API for Gocab service.
+// All implementations must embed UnimplementedGocabServer
+// for forward compatibility
+type GocabServer interface {
+	AddWord(context.Context, *AddWordRequest) (*Word, error)
+	// UpdateWord replaces the definition of a word, keeping its progress
+	UpdateWord(context.Context, *UpdateWordRequest) (*Word, error)
+	HasWord(context.Context, *HasWordRequest) (*HasWordResponse, error)
+	DeleteWord(context.Context, *DeleteWordRequest) (*DeleteWordResponse, error)
+	ListWords(context.Context, *ListWordsRequest) (*ListWordsResponse, error)
+	SearchWords(context.Context, *SearchWordsRequest) (*SearchWordsResponse, error)
+	// ImportWords adds new words and updates known ones
+	ImportWords(context.Context, *ImportWordsRequest) (*ImportWordsResponse, error)
+	GetSettings(context.Context, *GetSettingsRequest) (*Settings, error)
+	SaveSettings(context.Context, *Settings) (*Settings, error)
+	// TakeQuiz starts a quiz with the options sent first, then streams a
+	// question for every answer sent, each answer being graded and saved
+	// right away. The summary follows the grade of the last answer and ends
+	// the stream.
+	TakeQuiz(Gocab_TakeQuizServer) error
+	mustEmbedUnimplementedGocabServer()
+}
+
+// UnimplementedGocabServer must be embedded to have forward compatible implementations.
+type UnimplementedGocabServer struct {
+}
+
+func (UnimplementedGocabServer) AddWord(context.Context, *AddWordRequest) (*Word, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWord not implemented")
+}
+func (UnimplementedGocabServer) UpdateWord(context.Context, *UpdateWordRequest) (*Word, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWord not implemented")
+}
+func (UnimplementedGocabServer) HasWord(context.Context, *HasWordRequest) (*HasWordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasWord not implemented")
+}
+func (UnimplementedGocabServer) DeleteWord(context.Context, *DeleteWordRequest) (*DeleteWordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWord not implemented")
+}
+func (UnimplementedGocabServer) ListWords(context.Context, *ListWordsRequest) (*ListWordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWords not implemented")
+}
+func (UnimplementedGocabServer) SearchWords(context.Context, *SearchWordsRequest) (*SearchWordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchWords not implemented")
+}
+func (UnimplementedGocabServer) ImportWords(context.Context, *ImportWordsRequest) (*ImportWordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportWords not implemented")
+}
+func (UnimplementedGocabServer) GetSettings(context.Context, *GetSettingsRequest) (*Settings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSettings not implemented")
+}
+func (UnimplementedGocabServer) SaveSettings(context.Context, *Settings) (*Settings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveSettings not implemented")
+}
+func (UnimplementedGocabServer) TakeQuiz(Gocab_TakeQuizServer) error {
+	return status.Errorf(codes.Unimplemented, "method TakeQuiz not implemented")
+}
+func (UnimplementedGocabServer) mustEmbedUnimplementedGocabServer() {}
+
+// UnsafeGocabServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GocabServer will
+// result in compilation errors.
+type UnsafeGocabServer interface {
+	mustEmbedUnimplementedGocabServer()
+}
+
+func RegisterGocabServer(s grpc.ServiceRegistrar, srv GocabServer) {
+	s.RegisterService(&Gocab_ServiceDesc, srv)
+}
+
+func _Gocab_AddWord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GocabServer).AddWord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gocab_AddWord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GocabServer).AddWord(ctx, req.(*AddWordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gocab_UpdateWord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GocabServer).UpdateWord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gocab_UpdateWord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GocabServer).UpdateWord(ctx, req.(*UpdateWordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gocab_HasWord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HasWordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GocabServer).HasWord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gocab_HasWord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GocabServer).HasWord(ctx, req.(*HasWordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gocab_DeleteWord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GocabServer).DeleteWord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gocab_DeleteWord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GocabServer).DeleteWord(ctx, req.(*DeleteWordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gocab_ListWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GocabServer).ListWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gocab_ListWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GocabServer).ListWords(ctx, req.(*ListWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gocab_SearchWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GocabServer).SearchWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gocab_SearchWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GocabServer).SearchWords(ctx, req.(*SearchWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gocab_ImportWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GocabServer).ImportWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gocab_ImportWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GocabServer).ImportWords(ctx, req.(*ImportWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gocab_GetSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GocabServer).GetSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gocab_GetSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GocabServer).GetSettings(ctx, req.(*GetSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gocab_SaveSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Settings)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GocabServer).SaveSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Gocab_SaveSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GocabServer).SaveSettings(ctx, req.(*Settings))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gocab_TakeQuiz_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GocabServer).TakeQuiz(&gocabTakeQuizServer{stream})
+}
+
+type Gocab_TakeQuizServer interface {
+	Send(*TakeQuizResponse) error
+	Recv() (*TakeQuizRequest, error)
+	grpc.ServerStream
+}
+
+type gocabTakeQuizServer struct {
+	grpc.ServerStream
+}
+
+func (x *gocabTakeQuizServer) Send(m *TakeQuizResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gocabTakeQuizServer) Recv() (*TakeQuizRequest, error) {
+	m := new(TakeQuizRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Gocab_ServiceDesc is the grpc.ServiceDesc for Gocab service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Gocab_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gocab.Gocab",
+	HandlerType: (*GocabServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddWord",
+			Handler:    _Gocab_AddWord_Handler,
+		},
+		{
+			MethodName: "UpdateWord",
+			Handler:    _Gocab_UpdateWord_Handler,
+		},
+		{
+			MethodName: "HasWord",
+			Handler:    _Gocab_HasWord_Handler,
+		},
+		{
+			MethodName: "DeleteWord",
+			Handler:    _Gocab_DeleteWord_Handler,
+		},
+		{
+			MethodName: "ListWords",
+			Handler:    _Gocab_ListWords_Handler,
+		},
+		{
+			MethodName: "SearchWords",
+			Handler:    _Gocab_SearchWords_Handler,
+		},
+		{
+			MethodName: "ImportWords",
+			Handler:    _Gocab_ImportWords_Handler,
+		},
+		{
+			MethodName: "GetSettings",
+			Handler:    _Gocab_GetSettings_Handler,
+		},
+		{
+			MethodName: "SaveSettings",
+			Handler:    _Gocab_SaveSettings_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TakeQuiz",
+			Handler:       _Gocab_TakeQuiz_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "gocab.proto",
+}
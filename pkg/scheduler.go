@@ -0,0 +1,267 @@
+package pkg
+
+import (
+	"math"
+	"time"
+)
+
+// Rating tells a scheduler how well a word was remembered
+type Rating int
+
+const (
+	AGAIN Rating = iota + 1
+	HARD
+	GOOD
+	EASY
+)
+
+// Names of the available schedulers
+const SM2 = "sm2"
+const FSRS = "fsrs"
+const LEITNER = "leitner"
+
+const DEFAULT_EASE = 2.5
+const MIN_EASE = 1.3
+
+type Scheduler interface {
+	// Schedule updates the word's scheduling state after it has been
+	// reviewed at the given time
+	Schedule(word *Word, rating Rating, now time.Time)
+}
+
+// rate grades an answer from its outcome and how long it took to give, near
+// misses and nouns given with the wrong article being rated as hard
+func rate(question *Question) Rating {
+	switch question.Check() {
+	case WRONG:
+		return AGAIN
+	case ALMOST, WRONG_ARTICLE:
+		return HARD
+	}
+
+	switch {
+	case question.Duration == 0:
+		return GOOD
+	case question.Duration < EASY_RESPONSE:
+		return EASY
+	case question.Duration > HARD_RESPONSE:
+		return HARD
+	}
+
+	return GOOD
+}
+
+// sm2Scheduler implements the SuperMemo 2 algorithm
+type sm2Scheduler struct{}
+
+func NewSM2Scheduler() *sm2Scheduler {
+	return &sm2Scheduler{}
+}
+
+func (s *sm2Scheduler) Schedule(word *Word, rating Rating, now time.Time) {
+	quality := s.quality(rating)
+
+	if word.Ease == 0 {
+		word.Ease = DEFAULT_EASE
+	}
+
+	if quality < 3 {
+		word.Repetitions = 0
+		word.Interval = 1
+	} else {
+		// Words recalled before intervals were stored have repetitions but
+		// no interval to grow, and take the second step again
+		switch {
+		case word.Repetitions == 0:
+			word.Interval = 1
+		case word.Repetitions == 1 || word.Interval == 0:
+			word.Interval = 6
+		default:
+			word.Interval = int(math.Round(float64(word.Interval) * word.Ease))
+		}
+		word.Repetitions++
+	}
+
+	word.Ease += 0.1 - float64(5-quality)*(0.08+float64(5-quality)*0.02)
+	if word.Ease < MIN_EASE {
+		word.Ease = MIN_EASE
+	}
+
+	word.Reviewed = now
+	word.Due = now.AddDate(0, 0, word.Interval)
+	word.Score = math.Min(float64(word.Repetitions)*0.5, 1)
+}
+
+// quality maps a rating to SM-2's 0-5 response quality scale
+func (s *sm2Scheduler) quality(rating Rating) int {
+	switch rating {
+	case EASY:
+		return 5
+	case GOOD:
+		return 4
+	case HARD:
+		return 3
+	}
+	return 1
+}
+
+const DEFAULT_RETENTION = 0.9
+
+// Answers given faster or slower than these are rated as easy or hard
+const EASY_RESPONSE = 4 * time.Second
+const HARD_RESPONSE = 15 * time.Second
+
+// Stability in days from which a word is considered learned
+const MATURE_STABILITY = 21
+
+// Default FSRS v4.5 model weights
+var fsrsWeights = [17]float64{
+	0.4872, 1.4003, 3.7145, 13.8206, 5.1618, 1.2298, 0.8975, 0.031, 1.6474,
+	0.1367, 1.0461, 2.1072, 0.0793, 0.3246, 1.587, 0.2272, 2.8755,
+}
+
+const fsrsDecay = -0.5
+const fsrsFactor = 19.0 / 81.0
+
+// fsrsScheduler implements the Free Spaced Repetition Scheduler, spacing
+// reviews so words are recalled with the target retention probability
+type fsrsScheduler struct {
+	retention float64
+	weights   [17]float64
+}
+
+func NewFSRSScheduler(retention float64) *fsrsScheduler {
+	if retention <= 0 || retention >= 1 {
+		retention = DEFAULT_RETENTION
+	}
+	return &fsrsScheduler{retention, fsrsWeights}
+}
+
+func (s *fsrsScheduler) Schedule(word *Word, rating Rating, now time.Time) {
+	if word.Stability == 0 {
+		word.Stability = s.weights[rating-1]
+		word.Difficulty = s.initialDifficulty(rating)
+	} else {
+		elapsed := 0.0
+		if !word.Reviewed.IsZero() {
+			elapsed = math.Max(now.Sub(word.Reviewed).Hours()/24, 0)
+		}
+
+		retrievability := s.retrievability(elapsed, word.Stability)
+
+		if rating == AGAIN {
+			word.Stability = s.forgetStability(word.Difficulty, word.Stability, retrievability)
+		} else {
+			word.Stability = s.recallStability(word.Difficulty, word.Stability, retrievability, rating)
+		}
+
+		word.Difficulty = s.nextDifficulty(word.Difficulty, rating)
+	}
+
+	if rating == AGAIN {
+		word.Repetitions = 0
+	} else {
+		word.Repetitions++
+	}
+
+	interval := math.Round(word.Stability / fsrsFactor * (math.Pow(s.retention, 1/fsrsDecay) - 1))
+	word.Interval = int(math.Max(math.Min(interval, 36500), 1))
+	word.Reviewed = now
+	word.Due = now.AddDate(0, 0, word.Interval)
+	word.Score = math.Min(word.Stability/MATURE_STABILITY, 1)
+}
+
+// retrievability is the probability of recalling a word after the given
+// number of days
+func (s *fsrsScheduler) retrievability(elapsed, stability float64) float64 {
+	return math.Pow(1+fsrsFactor*elapsed/stability, fsrsDecay)
+}
+
+func (s *fsrsScheduler) initialDifficulty(rating Rating) float64 {
+	return s.clampDifficulty(s.weights[4] - float64(rating-3)*s.weights[5])
+}
+
+func (s *fsrsScheduler) nextDifficulty(difficulty float64, rating Rating) float64 {
+	next := difficulty - s.weights[6]*float64(rating-3)
+
+	// Mean reversion towards the difficulty of a first "good" answer
+	return s.clampDifficulty(s.weights[7]*s.initialDifficulty(GOOD) + (1-s.weights[7])*next)
+}
+
+func (s *fsrsScheduler) clampDifficulty(difficulty float64) float64 {
+	return math.Min(math.Max(difficulty, 1), 10)
+}
+
+func (s *fsrsScheduler) recallStability(difficulty, stability, retrievability float64, rating Rating) float64 {
+	modifier := 1.0
+	if rating == HARD {
+		modifier = s.weights[15]
+	} else if rating == EASY {
+		modifier = s.weights[16]
+	}
+
+	return stability * (1 + math.Exp(s.weights[8])*
+		(11-difficulty)*
+		math.Pow(stability, -s.weights[9])*
+		(math.Exp(s.weights[10]*(1-retrievability))-1)*
+		modifier)
+}
+
+func (s *fsrsScheduler) forgetStability(difficulty, stability, retrievability float64) float64 {
+	return s.weights[11] *
+		math.Pow(difficulty, -s.weights[12]) *
+		(math.Pow(stability+1, s.weights[13]) - 1) *
+		math.Exp(s.weights[14]*(1-retrievability))
+}
+
+const DEFAULT_BOXES = 5
+
+// leitnerScheduler moves a word up one box when it is remembered and back
+// to the first box when it is forgotten, each box having its own interval
+type leitnerScheduler struct {
+	intervals []int
+}
+
+func NewLeitnerScheduler(intervals []int) *leitnerScheduler {
+	if len(intervals) == 0 {
+		intervals = leitnerIntervals(DEFAULT_BOXES)
+	}
+	return &leitnerScheduler{intervals}
+}
+
+// leitnerIntervals doubles the interval, in days, of each following box
+func leitnerIntervals(boxes int) []int {
+	intervals := make([]int, boxes)
+	for i := range intervals {
+		intervals[i] = 1 << i
+	}
+	return intervals
+}
+
+func (s *leitnerScheduler) Schedule(word *Word, rating Rating, now time.Time) {
+	if rating == AGAIN {
+		word.Box = 1
+		word.Repetitions = 0
+	} else {
+		// New words start in the first box, words left in boxes removed
+		// since then moving to the last one
+		if word.Box == 0 {
+			word.Box = 1
+		} else if word.Box > len(s.intervals) {
+			word.Box = len(s.intervals)
+		}
+
+		if word.Box < len(s.intervals) {
+			word.Box++
+		}
+		word.Repetitions++
+	}
+
+	word.Interval = s.intervals[word.Box-1]
+	word.Reviewed = now
+	word.Due = now.AddDate(0, 0, word.Interval)
+	word.Score = 1
+	if len(s.intervals) > 1 {
+		word.Score = float64(word.Box-1) / float64(len(s.intervals)-1)
+	}
+}
@@ -0,0 +1,226 @@
+package pkg_test
+
+import (
+	"testing"
+	"time"
+
+	"example.com/gocab/pkg"
+)
+
+func TestSM2Scheduler(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("correct answers", func(t *testing.T) {
+		scheduler := pkg.NewSM2Scheduler()
+		word := &pkg.Word{Lang: "german", Word: "Haus"}
+
+		expected := []int{1, 6, 15}
+		for i, interval := range expected {
+			scheduler.Schedule(word, pkg.GOOD, now)
+
+			if word.Interval != interval {
+				t.Errorf("expected interval %d after %d reviews, got %d", interval, i+1, word.Interval)
+			}
+		}
+
+		if word.Repetitions != 3 {
+			t.Errorf("expected %d repetitions, got %d", 3, word.Repetitions)
+		}
+
+		if !word.Due.Equal(now.AddDate(0, 0, 15)) {
+			t.Errorf("expected due %v, got %v", now.AddDate(0, 0, 15), word.Due)
+		}
+
+		if word.Level() != "Easy" {
+			t.Errorf("expected level %s, got %s", "Easy", word.Level())
+		}
+	})
+
+	t.Run("mistake", func(t *testing.T) {
+		scheduler := pkg.NewSM2Scheduler()
+		word := &pkg.Word{Lang: "german", Word: "Haus", Ease: 2.5, Interval: 16, Repetitions: 3, Score: 1}
+
+		scheduler.Schedule(word, pkg.AGAIN, now)
+
+		if word.Repetitions != 0 {
+			t.Errorf("expected repetitions to reset, got %d", word.Repetitions)
+		}
+
+		if word.Interval != 1 {
+			t.Errorf("expected interval %d, got %d", 1, word.Interval)
+		}
+
+		if word.Ease >= 2.5 {
+			t.Errorf("expected ease to decrease, got %f", word.Ease)
+		}
+
+		if word.Level() != "Hard" {
+			t.Errorf("expected level %s, got %s", "Hard", word.Level())
+		}
+	})
+
+	t.Run("missing interval", func(t *testing.T) {
+		scheduler := pkg.NewSM2Scheduler()
+		word := &pkg.Word{Lang: "german", Word: "Haus", Ease: 2.5, Repetitions: 2, Score: 1}
+
+		scheduler.Schedule(word, pkg.GOOD, now)
+
+		if word.Interval != 6 {
+			t.Errorf("expected interval %d, got %d", 6, word.Interval)
+		}
+	})
+
+	t.Run("minimum ease", func(t *testing.T) {
+		scheduler := pkg.NewSM2Scheduler()
+		word := &pkg.Word{Lang: "german", Word: "Haus"}
+
+		for i := 0; i < 10; i++ {
+			scheduler.Schedule(word, pkg.AGAIN, now)
+		}
+
+		if word.Ease != pkg.MIN_EASE {
+			t.Errorf("expected ease %f, got %f", pkg.MIN_EASE, word.Ease)
+		}
+	})
+}
+
+func TestFSRSScheduler(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("first review", func(t *testing.T) {
+		scheduler := pkg.NewFSRSScheduler(0.9)
+		word := &pkg.Word{Lang: "german", Word: "Haus"}
+
+		scheduler.Schedule(word, pkg.GOOD, now)
+
+		if word.Stability < 3.7 || word.Stability > 3.8 {
+			t.Errorf("expected stability around %f, got %f", 3.7145, word.Stability)
+		}
+
+		// At 90% retention the interval matches the stability
+		if word.Interval != 4 {
+			t.Errorf("expected interval %d, got %d", 4, word.Interval)
+		}
+
+		if !word.Reviewed.Equal(now) {
+			t.Errorf("expected reviewed at %v, got %v", now, word.Reviewed)
+		}
+	})
+
+	t.Run("growing intervals", func(t *testing.T) {
+		scheduler := pkg.NewFSRSScheduler(0.9)
+		word := &pkg.Word{Lang: "german", Word: "Haus"}
+
+		reviewed := now
+		previous := 0
+		for i := 0; i < 4; i++ {
+			scheduler.Schedule(word, pkg.GOOD, reviewed)
+
+			if word.Interval <= previous {
+				t.Fatalf("expected interval to grow past %d, got %d", previous, word.Interval)
+			}
+
+			previous = word.Interval
+			reviewed = word.Due
+		}
+	})
+
+	t.Run("forgotten", func(t *testing.T) {
+		scheduler := pkg.NewFSRSScheduler(0.9)
+		word := &pkg.Word{Lang: "german", Word: "Haus"}
+
+		scheduler.Schedule(word, pkg.GOOD, now)
+		stability, difficulty := word.Stability, word.Difficulty
+
+		scheduler.Schedule(word, pkg.AGAIN, word.Due)
+
+		if word.Stability >= stability {
+			t.Errorf("expected stability to drop below %f, got %f", stability, word.Stability)
+		}
+
+		if word.Difficulty <= difficulty {
+			t.Errorf("expected difficulty to rise above %f, got %f", difficulty, word.Difficulty)
+		}
+
+		if word.Repetitions != 0 {
+			t.Errorf("expected repetitions to reset, got %d", word.Repetitions)
+		}
+	})
+
+	t.Run("retention", func(t *testing.T) {
+		relaxed := &pkg.Word{Lang: "german", Word: "Haus"}
+		strict := &pkg.Word{Lang: "german", Word: "Haus"}
+
+		pkg.NewFSRSScheduler(0.8).Schedule(relaxed, pkg.EASY, now)
+		pkg.NewFSRSScheduler(0.95).Schedule(strict, pkg.EASY, now)
+
+		if strict.Interval >= relaxed.Interval {
+			t.Errorf("expected higher retention to review sooner, got %d and %d", strict.Interval, relaxed.Interval)
+		}
+	})
+}
+
+func TestLeitnerScheduler(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("move up", func(t *testing.T) {
+		scheduler := pkg.NewLeitnerScheduler([]int{1, 3, 7})
+		word := &pkg.Word{Lang: "german", Word: "Haus"}
+
+		expected := []struct{ box, interval int }{{2, 3}, {3, 7}, {3, 7}}
+		for i, e := range expected {
+			scheduler.Schedule(word, pkg.GOOD, now)
+
+			if word.Box != e.box || word.Interval != e.interval {
+				t.Errorf("expected box %d with interval %d after %d reviews, got %d and %d", e.box, e.interval, i+1, word.Box, word.Interval)
+			}
+		}
+
+		if word.Level() != "Easy" {
+			t.Errorf("expected level %s, got %s", "Easy", word.Level())
+		}
+	})
+
+	t.Run("back to first box", func(t *testing.T) {
+		scheduler := pkg.NewLeitnerScheduler([]int{1, 3, 7})
+		word := &pkg.Word{Lang: "german", Word: "Haus", Box: 3}
+
+		scheduler.Schedule(word, pkg.AGAIN, now)
+
+		if word.Box != 1 || word.Interval != 1 {
+			t.Errorf("expected box %d with interval %d, got %d and %d", 1, 1, word.Box, word.Interval)
+		}
+
+		if !word.Due.Equal(now.AddDate(0, 0, 1)) {
+			t.Errorf("expected due %v, got %v", now.AddDate(0, 0, 1), word.Due)
+		}
+	})
+
+	t.Run("fewer boxes", func(t *testing.T) {
+		scheduler := pkg.NewLeitnerScheduler([]int{1, 3, 7})
+		word := &pkg.Word{Lang: "german", Word: "Haus", Box: 5}
+
+		scheduler.Schedule(word, pkg.GOOD, now)
+
+		if word.Box != 3 || word.Interval != 7 {
+			t.Errorf("expected box %d with interval %d, got %d and %d", 3, 7, word.Box, word.Interval)
+		}
+
+		if word.Level() != "Easy" {
+			t.Errorf("expected level %s, got %s", "Easy", word.Level())
+		}
+	})
+
+	t.Run("default boxes", func(t *testing.T) {
+		scheduler := pkg.NewLeitnerScheduler(nil)
+		word := &pkg.Word{Lang: "german", Word: "Haus"}
+
+		for i := 0; i < 10; i++ {
+			scheduler.Schedule(word, pkg.GOOD, now)
+		}
+
+		if word.Box != pkg.DEFAULT_BOXES || word.Interval != 16 {
+			t.Errorf("expected box %d with interval %d, got %d and %d", pkg.DEFAULT_BOXES, 16, word.Box, word.Interval)
+		}
+	})
+}
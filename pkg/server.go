@@ -0,0 +1,536 @@
+package pkg
+
+import (
+	"crypto/rand"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrQuizNotFound     = errors.New("quiz not found")
+	ErrUnknownQuestion  = errors.New("unknown question")
+	ErrQuestionAnswered = errors.New("question already answered")
+	ErrInvalidRequest   = errors.New("invalid request")
+	ErrNotFound         = errors.New("not found")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+// webFiles is the quiz web UI, taking quizzes through the API
+//
+//go:embed web
+var webFiles embed.FS
+
+// QUIZ_LIFETIME is how long quizzes left unfinished are kept
+const QUIZ_LIFETIME = 24 * time.Hour
+
+// questionTypes names the types of questions in the API
+var questionTypes = map[int]string{
+	FOREIGN_TO_ENGLISH: "foreign_to_english",
+	ENGLISH_TO_FOREIGN: "english_to_foreign",
+	MULTIPLE_CHOICE:    "multiple_choice",
+	CLOZE:              "cloze",
+	ARTICLE:            "article",
+}
+
+// QuizRequest creates a quiz, as the options of the quiz command
+type QuizRequest struct {
+	Lang      string   `json:"lang"`
+	Tags      []string `json:"tags"`
+	Scheduler string   `json:"scheduler"`
+	Strict    bool     `json:"strict"`
+	Articles  string   `json:"articles"`
+	Choices   int      `json:"choices"`
+}
+
+type QuizResponse struct {
+	ID        string              `json:"id"`
+	Questions []*QuestionResponse `json:"questions"`
+}
+
+// QuestionResponse is a question without its answer, identified by its
+// position in the quiz
+type QuestionResponse struct {
+	ID      int      `json:"id"`
+	Type    string   `json:"type"`
+	Level   string   `json:"level"`
+	Text    string   `json:"text"`
+	Choices []string `json:"choices,omitempty"`
+}
+
+type AnswerRequest struct {
+	Question int    `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// AnswerResponse grades an answer, the summary of the quiz being sent
+// along the grade of its last answer
+type AnswerResponse struct {
+	Result        string           `json:"result"`
+	Correct       bool             `json:"correct"`
+	Expected      string           `json:"expected"`
+	Pronunciation string           `json:"pronunciation,omitempty"`
+	Summary       *SummaryResponse `json:"summary,omitempty"`
+}
+
+type SummaryResponse struct {
+	Total         int    `json:"total"`
+	Correct       int    `json:"correct"`
+	NearMisses    int    `json:"near_misses"`
+	WrongArticles int    `json:"wrong_articles"`
+	Mistakes      int    `json:"mistakes"`
+	Text          string `json:"text"`
+}
+
+type ImportResponse struct {
+	Imported int               `json:"imported"`
+	Rejected map[string]string `json:"rejected"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server exposes the service as a JSON API
+type Server struct {
+	service Service
+
+	// tokens authenticate the API requests, every request being made for
+	// the service's user when nil
+	tokens TokenRepository
+
+	// mutex serializes requests, repositories not being safe for
+	// concurrent use. It is shared with the gRPC server of the same service.
+	mutex   *sync.Mutex
+	quizzes map[string]*quiz
+	files   http.Handler
+}
+
+// quiz is a quiz being taken, answers being saved as they are given
+type quiz struct {
+	questions []*Question
+	summary   *Summary
+	created   time.Time
+
+	// user took the quiz, who alone can answer it
+	user string
+
+	// asked is when the last question was answered, to time the next one
+	asked time.Time
+}
+
+func NewServer(service Service) *Server {
+	return NewServerWithTokens(service, nil)
+}
+
+// NewServerWithTokens creates a server requiring a token in the
+// Authorization header of API requests, which are then made for the user
+// of the token
+func NewServerWithTokens(service Service, tokens TokenRepository) *Server {
+	return NewServerWithMutex(service, tokens, &sync.Mutex{})
+}
+
+// NewServerWithMutex creates a server holding the given mutex while
+// handling requests, for other servers of the service to share it
+func NewServerWithMutex(service Service, tokens TokenRepository, mutex *sync.Mutex) *Server {
+	files, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		panic(err)
+	}
+
+	return &Server{
+		service: service,
+		tokens:  tokens,
+		mutex:   mutex,
+		quizzes: make(map[string]*quiz),
+		files:   http.FileServer(http.FS(files)),
+	}
+}
+
+// ServeHTTP routes requests to:
+//
+//	GET, POST           /languages/{lang}/words
+//	GET, PUT, DELETE    /languages/{lang}/words/{word}
+//	POST                /quizzes
+//	POST                /quizzes/{id}/answers
+//
+// Other paths serve the web UI, which is not authenticated.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	segments := make([]string, 0)
+	for _, part := range strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/") {
+		segment, err := url.PathUnescape(part)
+		if err != nil {
+			writeError(w, fmt.Errorf("%w: %v", ErrInvalidRequest, err))
+			return
+		}
+		segments = append(segments, segment)
+	}
+
+	if segments[0] != "languages" && segments[0] != "quizzes" {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			s.files.ServeHTTP(w, r)
+			return
+		}
+
+		writeError(w, ErrNotFound)
+		return
+	}
+
+	service, user, err := authenticate(s.service, s.tokens, r.Header.Get("Authorization"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var status int
+	var response any
+
+	switch {
+	case len(segments) == 3 && segments[0] == "languages" && segments[2] == "words":
+		status, response, err = s.words(r, service, segments[1])
+	case len(segments) == 4 && segments[0] == "languages" && segments[2] == "words":
+		status, response, err = s.word(r, service, segments[1], segments[3])
+	case len(segments) == 1 && segments[0] == "quizzes":
+		status, response, err = s.createQuiz(r, service, user)
+	case len(segments) == 3 && segments[0] == "quizzes" && segments[2] == "answers":
+		status, response, err = s.answer(r, service, user, segments[1])
+	default:
+		err = ErrNotFound
+	}
+
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, status, response)
+}
+
+func (s *Server) words(r *http.Request, service Service, lang string) (int, any, error) {
+	switch r.Method {
+	case http.MethodGet:
+		query := r.URL.Query()
+
+		tags := make([]string, 0)
+		for _, tag := range query["tags"] {
+			tags = append(tags, strings.Split(tag, ",")...)
+		}
+
+		limit := 0
+		if text := query.Get("limit"); text != "" {
+			var err error
+			if limit, err = strconv.Atoi(text); err != nil {
+				return 0, nil, fmt.Errorf("%w: limit %s", ErrInvalidRequest, text)
+			}
+		}
+
+		words, err := service.ListWords(lang, tags, query.Get("level"), query.Get("sort"), limit)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if words == nil {
+			words = make([]*Word, 0)
+		}
+
+		return http.StatusOK, words, nil
+	case http.MethodPost:
+		var body json.RawMessage
+		if err := decode(r, &body); err != nil {
+			return 0, nil, err
+		}
+
+		// A list of words is imported, updating the known ones
+		if strings.HasPrefix(strings.TrimSpace(string(body)), "[") {
+			return s.importWords(service, lang, body)
+		}
+
+		word := &Word{}
+		if err := json.Unmarshal(body, word); err != nil {
+			return 0, nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+		}
+		word.Lang = lang
+
+		added, err := service.AddWord(word)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		return http.StatusCreated, added, nil
+	}
+
+	return 0, nil, ErrMethodNotAllowed
+}
+
+func (s *Server) importWords(service Service, lang string, body []byte) (int, any, error) {
+	words := make([]*Word, 0)
+	if err := json.Unmarshal(body, &words); err != nil {
+		return 0, nil, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+
+	for _, word := range words {
+		word.Lang = lang
+	}
+
+	response := &ImportResponse{Rejected: make(map[string]string)}
+	for word, err := range service.ImportWords(words) {
+		if errors.Is(err, ErrReadOnly) {
+			return 0, nil, err
+		}
+		response.Rejected[word] = err.Error()
+	}
+	response.Imported = len(words) - len(response.Rejected)
+
+	return http.StatusOK, response, nil
+}
+
+func (s *Server) word(r *http.Request, service Service, lang, text string) (int, any, error) {
+	switch r.Method {
+	case http.MethodGet:
+		word, err := s.findWord(service, lang, text)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		return http.StatusOK, word, nil
+	case http.MethodPut:
+		word := &Word{}
+		if err := decode(r, word); err != nil {
+			return 0, nil, err
+		}
+		article, text := SplitArticle(lang, text)
+		if word.Article == "" {
+			word.Article = article
+		}
+		word.Lang = lang
+		word.Word = text
+
+		updated, err := service.UpdateWord(word)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		return http.StatusOK, updated, nil
+	case http.MethodDelete:
+		if err := service.DeleteWord(lang, text); err != nil {
+			return 0, nil, err
+		}
+
+		return http.StatusNoContent, nil, nil
+	}
+
+	return 0, nil, ErrMethodNotAllowed
+}
+
+// findWord looks a word up, written with or without its article
+func (s *Server) findWord(service Service, lang, text string) (*Word, error) {
+	words, err := service.ListWords(lang, nil, "", SORT_WORD, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Words are tried as typed first, phrases being registered that way
+	_, noun := splitArticle(lang, text, true)
+	for _, text := range []string{strings.TrimSpace(text), noun} {
+		for _, word := range words {
+			if word.Word == text {
+				return word, nil
+			}
+		}
+	}
+
+	return nil, ErrWordNotRegistered
+}
+
+func (s *Server) createQuiz(r *http.Request, service Service, user string) (int, any, error) {
+	if r.Method != http.MethodPost {
+		return 0, nil, ErrMethodNotAllowed
+	}
+
+	request := &QuizRequest{}
+	if err := decode(r, request); err != nil {
+		return 0, nil, err
+	}
+
+	questions, err := service.CreateQuiz(request.Lang, request.Tags, QuizOptions{
+		Scheduler: request.Scheduler,
+		Strict:    request.Strict,
+		Articles:  request.Articles,
+		Choices:   request.Choices,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	id, err := newQuizID()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	now := time.Now()
+	for id, quiz := range s.quizzes {
+		if now.Sub(quiz.created) > QUIZ_LIFETIME {
+			delete(s.quizzes, id)
+		}
+	}
+
+	s.quizzes[id] = &quiz{
+		questions: questions,
+		summary:   &Summary{Total: len(questions)},
+		created:   now,
+		user:      user,
+		asked:     now,
+	}
+
+	response := &QuizResponse{ID: id, Questions: make([]*QuestionResponse, len(questions))}
+	for i, question := range questions {
+		response.Questions[i] = &QuestionResponse{
+			ID:      i,
+			Type:    questionTypes[question.Type],
+			Level:   question.Level(),
+			Text:    strings.TrimSpace(question.Text()),
+			Choices: question.Choices,
+		}
+	}
+
+	return http.StatusCreated, response, nil
+}
+
+// answer grades an answer and saves it right away, so that the progress
+// made on quizzes left unfinished is kept
+func (s *Server) answer(r *http.Request, service Service, user, id string) (int, any, error) {
+	if r.Method != http.MethodPost {
+		return 0, nil, ErrMethodNotAllowed
+	}
+
+	quiz, ok := s.quizzes[id]
+	if !ok || quiz.user != user {
+		return 0, nil, ErrQuizNotFound
+	}
+
+	request := &AnswerRequest{}
+	if err := decode(r, request); err != nil {
+		return 0, nil, err
+	}
+
+	if request.Question < 0 || request.Question >= len(quiz.questions) {
+		return 0, nil, ErrUnknownQuestion
+	}
+
+	question := quiz.questions[request.Question]
+	if !question.Answered.IsZero() {
+		return 0, nil, ErrQuestionAnswered
+	}
+
+	question.Answer = request.Answer
+	question.Answered = time.Now()
+	question.Duration = question.Answered.Sub(quiz.asked)
+	quiz.asked = question.Answered
+
+	if err := service.SaveResult(&Summary{Total: 1, Questions: []*Question{question}}); err != nil {
+		question.Answered = time.Time{}
+		return 0, nil, err
+	}
+
+	result := quiz.summary.Record(question)
+
+	response := &AnswerResponse{
+		Result:        result.String(),
+		Correct:       question.IsCorrect(),
+		Expected:      question.ExpectedAnswer(),
+		Pronunciation: question.Word.Pronunciation,
+	}
+
+	if len(quiz.summary.Questions) == len(quiz.questions) {
+		response.Summary = newSummaryResponse(quiz.summary)
+		delete(s.quizzes, id)
+	}
+
+	return http.StatusOK, response, nil
+}
+
+func newSummaryResponse(summary *Summary) *SummaryResponse {
+	return &SummaryResponse{
+		Total:         summary.Total,
+		Correct:       summary.Total - summary.Mistakes - summary.NearMisses - summary.WrongArticles,
+		NearMisses:    summary.NearMisses,
+		WrongArticles: summary.WrongArticles,
+		Mistakes:      summary.Mistakes,
+		Text:          strings.TrimSpace(summary.String()),
+	}
+}
+
+func newQuizID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+func decode(r *http.Request, value any) error {
+	if err := json.NewDecoder(r.Body).Decode(value); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	if status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// writeError answers with the status matching the error
+func writeError(w http.ResponseWriter, err error) {
+	status := errorStatus(err)
+
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+
+	writeJSON(w, status, &errorResponse{err.Error()})
+}
+
+// errorStatus is the HTTP status matching an error
+func errorStatus(err error) int {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrQuizNotFound),
+		errors.Is(err, ErrWordNotRegistered), errors.Is(err, ErrNoWordsFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrMethodNotAllowed):
+		status = http.StatusMethodNotAllowed
+	case errors.Is(err, ErrUnauthorized):
+		status = http.StatusUnauthorized
+	case errors.Is(err, ErrReadOnly):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrWordAlreadyRegistered), errors.Is(err, ErrQuestionAnswered):
+		status = http.StatusConflict
+	case errors.Is(err, ErrInvalidRequest), errors.Is(err, ErrUnknownQuestion),
+		errors.Is(err, ErrUnknownLevel), errors.Is(err, ErrUnknownSort),
+		errors.Is(err, ErrUnknownScheduler), errors.Is(err, ErrUnknownArticles),
+		errors.Is(err, ErrInvalidRetention), errors.Is(err, ErrInvalidBoxes):
+		status = http.StatusBadRequest
+	}
+
+	return status
+}
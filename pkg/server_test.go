@@ -0,0 +1,322 @@
+package pkg_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"example.com/gocab/pkg"
+)
+
+// request sends a JSON body and decodes the response into result, if any
+func request(t *testing.T, server *httptest.Server, method, path string, body, result any) int {
+	t.Helper()
+
+	return requestWithToken(t, server, "", method, path, body, result)
+}
+
+// requestWithToken sends a request authenticated by a token, unless empty
+func requestWithToken(t *testing.T, server *httptest.Server, token, method, path string, body, result any) int {
+	t.Helper()
+
+	content, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	req, err := http.NewRequest(method, server.URL+path, bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	defer res.Body.Close()
+
+	if result != nil {
+		if err := json.NewDecoder(res.Body).Decode(result); err != nil {
+			t.Fatalf("expected a json response, got %v", err)
+		}
+	}
+
+	return res.StatusCode
+}
+
+func TestServerWords(t *testing.T) {
+	repository := pkg.NewInMemoryRepository()
+	server := httptest.NewServer(pkg.NewServer(pkg.NewService(repository)))
+	defer server.Close()
+
+	t.Run("add", func(t *testing.T) {
+		word := &pkg.Word{}
+		status := request(t, server, http.MethodPost, "/languages/german/words", map[string]any{
+			"word": "das Haus", "meanings": []map[string]string{{"text": "House"}}, "tags": []string{"noun"},
+		}, word)
+
+		if status != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d", http.StatusCreated, status)
+		}
+
+		if word.Word != "Haus" || word.Article != "das" || word.Lang != "german" {
+			t.Errorf("expected das Haus to be added, got %v", word)
+		}
+
+		status = request(t, server, http.MethodPost, "/languages/german/words", map[string]any{"word": "Haus"}, nil)
+		if status != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, status)
+		}
+	})
+
+	t.Run("import", func(t *testing.T) {
+		response := &pkg.ImportResponse{}
+		status := request(t, server, http.MethodPost, "/languages/german/words", []map[string]any{
+			{"word": "Mann", "meanings": []map[string]string{{"text": "Man"}}, "tags": []string{"noun"}},
+			{"word": "gehen", "meanings": []map[string]string{{"text": "to go"}}, "tags": []string{"verb"}},
+		}, response)
+
+		if status != http.StatusOK || response.Imported != 2 || len(response.Rejected) != 0 {
+			t.Errorf("expected 2 imported words, got %d and %v", status, response)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		words := make([]*pkg.Word, 0)
+		status := request(t, server, http.MethodGet, "/languages/german/words?tags=noun&sort=word", nil, &words)
+
+		if status != http.StatusOK || len(words) != 2 || words[0].Word != "Haus" || words[1].Word != "Mann" {
+			t.Errorf("expected Haus and Mann, got %d and %v", status, words)
+		}
+
+		status = request(t, server, http.MethodGet, "/languages/german/words?sort=length", nil, nil)
+		if status != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, status)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		word := &pkg.Word{}
+		status := request(t, server, http.MethodPut, "/languages/german/words/das%20Haus", map[string]any{
+			"meanings": []map[string]string{{"text": "House"}, {"text": "Home"}}, "tags": []string{"noun"},
+		}, word)
+
+		if status != http.StatusOK || word.Meaning() != "House; Home" {
+			t.Errorf("expected Haus to be updated, got %d and %v", status, word)
+		}
+
+		found := &pkg.Word{}
+		if status := request(t, server, http.MethodGet, "/languages/german/words/Haus", nil, found); status != http.StatusOK || found.Meaning() != "House; Home" {
+			t.Errorf("expected updated Haus, got %d and %v", status, found)
+		}
+
+		status = request(t, server, http.MethodPut, "/languages/german/words/Frau", map[string]any{}, nil)
+		if status != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, status)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if status := request(t, server, http.MethodDelete, "/languages/german/words/gehen", nil, nil); status != http.StatusNoContent {
+			t.Errorf("expected status %d, got %d", http.StatusNoContent, status)
+		}
+
+		if exists, _ := repository.HasWord("german", "gehen"); exists {
+			t.Error("should have deleted word \"gehen\"")
+		}
+
+		if status := request(t, server, http.MethodDelete, "/languages/german/words/gehen", nil, nil); status != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, status)
+		}
+	})
+
+	t.Run("routes", func(t *testing.T) {
+		if status := request(t, server, http.MethodGet, "/words", nil, nil); status != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, status)
+		}
+
+		if status := request(t, server, http.MethodPatch, "/languages/german/words", nil, nil); status != http.StatusMethodNotAllowed {
+			t.Errorf("expected status %d, got %d", http.StatusMethodNotAllowed, status)
+		}
+	})
+}
+
+func TestServerQuiz(t *testing.T) {
+	repository := pkg.NewInMemoryRepository()
+	service := pkg.NewService(repository)
+	server := httptest.NewServer(pkg.NewServer(service))
+	defer server.Close()
+
+	service.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+	service.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man"), Tags: []string{"noun"}})
+
+	quiz := &pkg.QuizResponse{}
+	status := request(t, server, http.MethodPost, "/quizzes", &pkg.QuizRequest{Lang: "german", Tags: []string{"noun"}, Choices: 1}, quiz)
+
+	if status != http.StatusCreated || quiz.ID == "" || len(quiz.Questions) != 2 {
+		t.Fatalf("expected a quiz of 2 questions, got %d and %v", status, quiz)
+	}
+
+	// Answers are the position of the picked choice in choice mode
+	answers := map[string]string{"House": "Haus", "Man": "Mann"}
+	question := quiz.Questions[0]
+
+	right := ""
+	for i, choice := range question.Choices {
+		if strings.Contains(question.Text, answers[choice]+" mean") {
+			right = strconv.Itoa(i + 1)
+		}
+	}
+
+	answer := &pkg.AnswerResponse{}
+	status = request(t, server, http.MethodPost, "/quizzes/"+quiz.ID+"/answers", &pkg.AnswerRequest{Question: 0, Answer: right}, answer)
+
+	if status != http.StatusOK || !answer.Correct || answer.Summary != nil {
+		t.Errorf("expected a right answer without summary, got %d and %v", status, answer)
+	}
+
+	if status := request(t, server, http.MethodPost, "/quizzes/"+quiz.ID+"/answers", &pkg.AnswerRequest{Question: 0, Answer: right}, nil); status != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, status)
+	}
+
+	if reviews, _ := repository.FindReviews("german", ""); len(reviews) != 1 {
+		t.Errorf("expected answer to be saved right away, got %d reviews", len(reviews))
+	}
+
+	answer = &pkg.AnswerResponse{}
+	request(t, server, http.MethodPost, "/quizzes/"+quiz.ID+"/answers", &pkg.AnswerRequest{Question: 1, Answer: "wrong"}, answer)
+
+	if answer.Correct || answer.Summary == nil || answer.Summary.Total != 2 || answer.Summary.Mistakes != 1 {
+		t.Errorf("expected a wrong answer and the summary, got %v", answer)
+	}
+
+	if status := request(t, server, http.MethodPost, "/quizzes/"+quiz.ID+"/answers", &pkg.AnswerRequest{Question: 1}, nil); status != http.StatusNotFound {
+		t.Errorf("expected finished quiz to be removed, got %d", status)
+	}
+
+	if status := request(t, server, http.MethodPost, "/quizzes", &pkg.QuizRequest{Lang: "french"}, nil); status != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, status)
+	}
+}
+
+func TestServerWebUI(t *testing.T) {
+	server := httptest.NewServer(pkg.NewServer(pkg.NewService(pkg.NewInMemoryRepository())))
+	defer server.Close()
+
+	for _, path := range []string{"/", "/app.js", "/style.css"} {
+		res, err := server.Client().Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		content, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			t.Errorf("expected %s to be served, got %d", path, res.StatusCode)
+		}
+
+		// Everything is embedded so that the UI works offline
+		if bytes.Contains(content, []byte("http://")) || bytes.Contains(content, []byte("https://")) {
+			t.Errorf("expected %s not to load external assets", path)
+		}
+	}
+}
+
+func TestServerTokens(t *testing.T) {
+	repository := pkg.NewInMemoryRepository()
+	service := pkg.NewService(repository)
+	server := httptest.NewServer(pkg.NewServerWithTokens(service, repository))
+	defer server.Close()
+
+	service.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+
+	newToken := func(user, scope string) string {
+		secret, token, err := pkg.NewToken(user, "", scope)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		repository.AddToken(token)
+
+		return secret
+	}
+
+	dashboard := newToken("alice", pkg.SCOPE_READ)
+	alice := newToken("alice", pkg.SCOPE_WRITE)
+	bob := newToken("bob", pkg.SCOPE_WRITE)
+
+	t.Run("unauthorized", func(t *testing.T) {
+		for _, token := range []string{"", "unknown"} {
+			if status := requestWithToken(t, server, token, http.MethodGet, "/languages/german/words", nil, nil); status != http.StatusUnauthorized {
+				t.Errorf("expected status %d for token %q, got %d", http.StatusUnauthorized, token, status)
+			}
+		}
+
+		if status := request(t, server, http.MethodGet, "/", nil, nil); status != http.StatusOK {
+			t.Errorf("expected the web UI to be served without token, got %d", status)
+		}
+	})
+
+	t.Run("read-only", func(t *testing.T) {
+		words := make([]*pkg.Word, 0)
+		if status := requestWithToken(t, server, dashboard, http.MethodGet, "/languages/german/words", nil, &words); status != http.StatusOK || len(words) != 1 {
+			t.Errorf("expected Haus to be listed, got %d and %v", status, words)
+		}
+
+		requests := []struct {
+			method, path string
+			body         any
+		}{
+			{http.MethodPost, "/languages/german/words", map[string]any{"word": "Mann"}},
+			{http.MethodPost, "/languages/german/words", []map[string]any{{"word": "Mann"}}},
+			{http.MethodPut, "/languages/german/words/Haus", map[string]any{}},
+			{http.MethodDelete, "/languages/german/words/Haus", nil},
+		}
+
+		for _, r := range requests {
+			if status := requestWithToken(t, server, dashboard, r.method, r.path, r.body, nil); status != http.StatusForbidden {
+				t.Errorf("expected status %d for %s %s, got %d", http.StatusForbidden, r.method, r.path, status)
+			}
+		}
+
+		quiz := &pkg.QuizResponse{}
+		requestWithToken(t, server, dashboard, http.MethodPost, "/quizzes", &pkg.QuizRequest{Lang: "german"}, quiz)
+
+		if status := requestWithToken(t, server, dashboard, http.MethodPost, "/quizzes/"+quiz.ID+"/answers", &pkg.AnswerRequest{Answer: "House"}, nil); status != http.StatusForbidden {
+			t.Errorf("expected status %d, got %d", http.StatusForbidden, status)
+		}
+	})
+
+	t.Run("users", func(t *testing.T) {
+		quiz := &pkg.QuizResponse{}
+		requestWithToken(t, server, bob, http.MethodPost, "/quizzes", &pkg.QuizRequest{Lang: "german", Choices: 1}, quiz)
+
+		if status := requestWithToken(t, server, alice, http.MethodPost, "/quizzes/"+quiz.ID+"/answers", &pkg.AnswerRequest{Answer: "1"}, nil); status != http.StatusNotFound {
+			t.Errorf("expected the quiz of bob to be hidden from alice, got %d", status)
+		}
+
+		if status := requestWithToken(t, server, bob, http.MethodPost, "/quizzes/"+quiz.ID+"/answers", &pkg.AnswerRequest{Answer: "1"}, nil); status != http.StatusOK {
+			t.Errorf("expected bob to answer, got %d", status)
+		}
+
+		scoped, _ := repository.ForUser("bob")
+		if reviews, _ := scoped.FindReviews("german", "Haus"); len(reviews) != 1 {
+			t.Errorf("expected the answer to be saved for bob, got %d reviews", len(reviews))
+		}
+
+		if reviews, _ := repository.FindReviews("german", "Haus"); len(reviews) != 0 {
+			t.Errorf("expected no reviews for the default user, got %d", len(reviews))
+		}
+	})
+}
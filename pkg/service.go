@@ -4,106 +4,587 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 var (
 	ErrWordAlreadyRegistered = errors.New("word already registered")
 	ErrWordNotRegistered     = errors.New("word not registered")
 	ErrNoWordsFound          = errors.New("no words found")
+	ErrUnknownScheduler      = errors.New("unknown scheduler")
+	ErrInvalidRetention      = errors.New("retention must be between 0 and 1")
+	ErrInvalidBoxes          = errors.New("invalid leitner boxes")
+	ErrUnknownLevel          = errors.New("unknown level")
+	ErrUnknownSort           = errors.New("unknown sort order")
+	ErrNoWordOrTag           = errors.New("a word or a tag is required")
+	ErrUnknownArticles       = errors.New("unknown article mode")
+	ErrInvalidUser           = errors.New("user name must not be empty")
 )
 
 const FOREIGN_TO_ENGLISH = 0
 const ENGLISH_TO_FOREIGN = 1
+const MULTIPLE_CHOICE = 2
+const CLOZE = 3
+const ARTICLE = 4
+
+// CLOZE_BLANK replaces the word in the example of cloze questions
+const CLOZE_BLANK = "___"
+
+// DEFAULT_CHOICES is the number of wrong choices in multiple choice questions
+const DEFAULT_CHOICES = 3
+
+const LEVEL_HARD = "hard"
+const LEVEL_MEDIUM = "medium"
+const LEVEL_EASY = "easy"
+
+// Fields a search can match
+const SEARCH_WORD = "word"
+const SEARCH_MEANING = "meaning"
+const SEARCH_PRONUNCIATION = "pronunciation"
+const SEARCH_EXAMPLE = "example"
+
+// How the article of nouns is quizzed
+const ARTICLES_IGNORE = "ignore"
+const ARTICLES_REQUIRE = "require"
+const ARTICLES_ASK = "ask"
+
+const SORT_WORD = "word"
+const SORT_SCORE = "score"
+const SORT_ADDED = "added"
 
 type Question struct {
-	Type   int
-	Word   *Word
-	Answer string
+	Type     int
+	Word     *Word
+	Answer   string
+	Answered time.Time
+	Duration time.Duration
+
+	// Scheduler reviewing the word, empty for the language's default
+	Scheduler string
+
+	// Meanings to pick from in multiple choice questions
+	Choices []string
+
+	// Example with the word blanked out and the forms it was found in,
+	// for cloze questions
+	Sentence string
+	Blanks   []string
+
+	// Matcher grading typed answers, DefaultMatcher when nil
+	Matcher *Matcher
+
+	// Strict requires answers to be typed with the language's special
+	// letters instead of accepting their normalized forms
+	Strict bool
+
+	// Articles tells whether nouns must be answered with their article,
+	// ARTICLES_IGNORE when empty
+	Articles string
 }
 
 func NewQuestion(word *Word) *Question {
 	rand.Seed(time.Now().UnixNano())
+
+	// Words used in their example are asked as cloze every now and then
+	if rand.Intn(3) == 0 {
+		if question, ok := NewClozeQuestion(word); ok {
+			return question
+		}
+	}
+
 	return &Question{Type: rand.Intn(2), Word: word}
 }
 
+// NewClozeQuestion blanks the word out of its example, failing when the
+// word cannot be found in it
+func NewClozeQuestion(word *Word) (*Question, bool) {
+	sentence, blanks := blankOut(word.Example, word.Word)
+	if len(blanks) == 0 {
+		return nil, false
+	}
+
+	return &Question{Type: CLOZE, Word: word, Sentence: sentence, Blanks: blanks}, true
+}
+
+type token struct {
+	text       string
+	start, end int
+}
+
+// tokenize splits text into words, keeping their position in the text
+func tokenize(text string) []token {
+	tokens := make([]token, 0)
+	start := -1
+
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+
+		if isWord && start < 0 {
+			start = i
+		} else if !isWord && start >= 0 {
+			tokens = append(tokens, token{text[start:i], start, i})
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		tokens = append(tokens, token{text[start:], start, len(text)})
+	}
+
+	return tokens
+}
+
+// blankOut replaces every occurrence of word in sentence, returning the
+// forms it was found in. The last word of an expression may be inflected.
+func blankOut(sentence, word string) (string, []string) {
+	words := tokenize(word)
+	tokens := tokenize(sentence)
+
+	if len(words) == 0 {
+		return sentence, nil
+	}
+
+	blanked := ""
+	last := 0
+	blanks := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for i := 0; i+len(words) <= len(tokens); i++ {
+		found := true
+		for j, w := range words {
+			if j == len(words)-1 {
+				found = found && isInflection(tokens[i+j].text, w.text)
+			} else {
+				found = found && strings.EqualFold(tokens[i+j].text, w.text)
+			}
+		}
+
+		if !found {
+			continue
+		}
+
+		start, end := tokens[i].start, tokens[i+len(words)-1].end
+		blank := sentence[start:end]
+
+		if !seen[strings.ToLower(blank)] {
+			seen[strings.ToLower(blank)] = true
+			blanks = append(blanks, blank)
+		}
+
+		blanked += sentence[last:start] + CLOZE_BLANK
+		last = end
+		i += len(words) - 1
+	}
+
+	return blanked + sentence[last:], blanks
+}
+
+// inflectionEndings are the endings an inflected form may have after the
+// word or its stem, as "st" in "gehst"
+var inflectionEndings = []string{"", "e", "n", "s", "t", "en", "er", "es", "em", "st", "et", "te", "ern", "est", "ten"}
+
+// isInflection tells whether token is word, ignoring case, or word with a
+// different ending, as in "gehen" and "gehst"
+func isInflection(token, word string) bool {
+	token = strings.ToLower(token)
+	word = strings.ToLower(word)
+
+	for _, stem := range []string{word, stemOf(word)} {
+		if len([]rune(stem)) < 3 || !strings.HasPrefix(token, stem) {
+			continue
+		}
+
+		// The rest of the token must be a known ending, so "Haus" is not
+		// found in "Haut" or "Haupt"
+		ending := strings.TrimPrefix(token, stem)
+		for _, known := range inflectionEndings {
+			if ending == known {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// stemOf drops the first common inflection ending of a lowercase word, as
+// "en" from "gehen". Single letters are only dropped from longer words, the
+// "s" of "haus" belonging to the noun.
+func stemOf(word string) string {
+	for _, suffix := range []string{"en", "er", "es", "e", "n", "s"} {
+		if !strings.HasSuffix(word, suffix) {
+			continue
+		}
+
+		stem := strings.TrimSuffix(word, suffix)
+		if len(suffix) == 1 && len([]rune(stem)) < 4 {
+			return word
+		}
+		return stem
+	}
+	return word
+}
+
+// NewArticleQuestion asks the article of a noun
+func NewArticleQuestion(word *Word) *Question {
+	return &Question{Type: ARTICLE, Word: word}
+}
+
+func NewChoiceQuestion(word *Word, distractors []string) *Question {
+	choices := append([]string{word.Meaning()}, distractors...)
+	rand.Shuffle(len(choices), func(i, j int) {
+		choices[i], choices[j] = choices[j], choices[i]
+	})
+
+	return &Question{Type: MULTIPLE_CHOICE, Word: word, Choices: choices}
+}
+
 func (q *Question) Text() string {
+	if q.Type == ARTICLE {
+		return fmt.Sprintf("[%s] What is the article of %s?\n", q.Level(), q.Word.Word)
+	}
+
+	if q.Type == MULTIPLE_CHOICE {
+		text := fmt.Sprintf("[%s] What does %s mean?\n", q.Level(), q.word())
+		for i, choice := range q.Choices {
+			text += fmt.Sprintf("  %d) %s\n", i+1, choice)
+		}
+		return text
+	}
+
+	if q.Type == CLOZE {
+		return fmt.Sprintf("[%s] Fill in the blank (%s): %s\n", q.Level(), q.Word.Meaning(), q.Sentence)
+	}
+
 	if q.Type == ENGLISH_TO_FOREIGN {
-		return fmt.Sprintf("[%s] How do you say \"%s\" in %s\n", q.Word.Level(), q.Word.Meaning, q.Word.Lang)
+		return fmt.Sprintf("[%s] How do you say \"%s\" in %s\n", q.Level(), q.Word.Meaning(), q.Word.Lang)
 	}
 
 	if q.Word.Pronunciation != "" {
-		return fmt.Sprintf("[%s] What does %s [%s] mean?\n", q.Word.Level(), q.Word.Word, q.Word.Pronunciation)
+		return fmt.Sprintf("[%s] What does %s [%s] mean?\n", q.Level(), q.word(), q.Word.Pronunciation)
 	}
 
-	return fmt.Sprintf("[%s] What does %s mean?\n", q.Word.Level(), q.Word.Word)
+	return fmt.Sprintf("[%s] What does %s mean?\n", q.Level(), q.word())
+}
+
+// word shows nouns with their article, unless it is asked separately
+func (q *Question) word() string {
+	if q.Articles == ARTICLES_ASK {
+		return q.Word.Word
+	}
+	return q.Word.WithArticle()
+}
+
+// Level shows the word's Leitner box when reviewed in boxes, its
+// difficulty otherwise
+func (q *Question) Level() string {
+	if q.Scheduler == LEITNER {
+		box := q.Word.Box
+		if box == 0 {
+			box = 1
+		}
+		return fmt.Sprintf("Box %d", box)
+	}
+	return q.Word.Level()
 }
 
 func (q *Question) ExpectedAnswer() string {
+	if q.Type == CLOZE {
+		return q.Blanks[0]
+	}
+
+	if q.Type == ARTICLE {
+		return q.Word.Article
+	}
+
 	if q.Type == ENGLISH_TO_FOREIGN {
+		if q.Articles == ARTICLES_REQUIRE {
+			return q.Word.WithArticle()
+		}
 		return q.Word.Word
 	}
-	return q.Word.Meaning
+	return q.Word.Meaning()
+}
+
+// GivenAnswer is the answer as typed, or the picked choice in multiple
+// choice questions
+func (q *Question) GivenAnswer() string {
+	answer := strings.TrimSpace(q.Answer)
+
+	if q.Type == MULTIPLE_CHOICE {
+		if choice, err := strconv.Atoi(answer); err == nil && choice > 0 && choice <= len(q.Choices) {
+			return q.Choices[choice-1]
+		}
+	}
+
+	return answer
 }
 
 func (q *Question) IsCorrect() bool {
-	for _, meaning := range strings.Split(q.ExpectedAnswer(), ",") {
-		if strings.TrimSpace(strings.ToLower(q.Answer)) == strings.TrimSpace(strings.ToLower(meaning)) {
-			return true
+	return q.Check() >= NORMALIZED
+}
+
+// Check grades the answer, accepting typos as near misses in typed answers
+// and, unless strict, answers typed without the language's special letters
+func (q *Question) Check() Result {
+	if q.Type == ARTICLE {
+		if fold(q.Answer) == fold(q.Word.Article) {
+			return EXACT
 		}
+		return WRONG
 	}
-	return false
+
+	if q.Type == MULTIPLE_CHOICE {
+		choice, err := strconv.Atoi(strings.TrimSpace(q.Answer))
+		if err == nil && choice > 0 && choice <= len(q.Choices) && q.Choices[choice-1] == q.ExpectedAnswer() {
+			return EXACT
+		}
+		return WRONG
+	}
+
+	matcher := q.Matcher
+	if matcher == nil {
+		matcher = DefaultMatcher
+	}
+
+	answer := q.Answer
+	article := ""
+
+	var candidates []string
+	if q.Type == CLOZE {
+		candidates = append(candidates, q.Blanks...)
+		candidates = append(candidates, q.Word.Word)
+	} else if q.Type == ENGLISH_TO_FOREIGN && q.Word.Article != "" {
+		// The noun is graded on its own, its article being checked after
+		article, answer = splitArticle(q.Word.Lang, answer, true)
+		candidates = []string{q.Word.Word}
+	} else if q.Type == FOREIGN_TO_ENGLISH {
+		// Any of the meanings is accepted, with or without its note
+		answer = ParseMeaning(answer).Text
+		for _, meaning := range q.Word.Meanings {
+			candidates = append(candidates, strings.Split(meaning.Text, ",")...)
+		}
+	} else {
+		candidates = strings.Split(q.ExpectedAnswer(), ",")
+	}
+
+	result := WRONG
+	for _, candidate := range candidates {
+		if r := q.match(matcher, answer, candidate); r > result {
+			result = r
+		}
+	}
+
+	if result >= NORMALIZED && q.Type == ENGLISH_TO_FOREIGN && q.Articles == ARTICLES_REQUIRE && q.Word.Article != "" {
+		if fold(article) != fold(q.Word.Article) {
+			return WRONG_ARTICLE
+		}
+	}
+
+	return result
+}
+
+func (q *Question) match(matcher *Matcher, given, expected string) Result {
+	result := matcher.Match(given, expected)
+	if result == EXACT || q.Strict {
+		return result
+	}
+
+	normalizer := NormalizerFor(q.Word.Lang)
+
+	for _, answer := range normalizer.Forms(given) {
+		for _, form := range normalizer.Forms(expected) {
+			switch matcher.Match(answer, form) {
+			case EXACT:
+				return NORMALIZED
+			case ALMOST:
+				result = ALMOST
+			}
+		}
+	}
+
+	return result
 }
 
 type Summary struct {
-	Total     int
-	Mistakes  int
-	Questions []*Question
+	Total         int
+	Mistakes      int
+	NearMisses    int
+	WrongArticles int
+	Questions     []*Question
 }
 
 func (s *Summary) Correct(question *Question) {
 	s.Questions = append(s.Questions, question)
 }
 
+// Almost records an answer with a few typos
+func (s *Summary) Almost(question *Question) {
+	s.NearMisses++
+	s.Questions = append(s.Questions, question)
+}
+
+// WrongArticle records a noun answered with the wrong article
+func (s *Summary) WrongArticle(question *Question) {
+	s.WrongArticles++
+	s.Questions = append(s.Questions, question)
+}
+
 func (s *Summary) Wrong(question *Question) {
 	s.Mistakes++
 	s.Questions = append(s.Questions, question)
 }
 
+// Record grades an answered question and records it accordingly
+func (s *Summary) Record(question *Question) Result {
+	result := question.Check()
+
+	switch result {
+	case EXACT, NORMALIZED:
+		s.Correct(question)
+	case ALMOST:
+		s.Almost(question)
+	case WRONG_ARTICLE:
+		s.WrongArticle(question)
+	default:
+		s.Wrong(question)
+	}
+
+	return result
+}
+
 func (s *Summary) String() string {
-	correct := s.Total - s.Mistakes
-	performance := (1 - float64(s.Mistakes)/float64(s.Total)) * 100
-
-	str := fmt.Sprintf("\nTotal: %d, Correct: %d, Mistakes: %d, Performance: %.0f%%\n", s.Total, correct, s.Mistakes, performance)
-
-	if s.Mistakes > 0 {
-		for _, question := range s.Questions {
-			if !question.IsCorrect() {
-				if question.Type == ENGLISH_TO_FOREIGN {
-					if question.Word.Pronunciation != "" {
-						str += fmt.Sprintf("%s -> %s [%s]\n", strings.TrimSpace(question.Answer), question.ExpectedAnswer(), question.Word.Pronunciation)
-					} else {
-						str += fmt.Sprintf("%s -> %s\n", strings.TrimSpace(question.Answer), question.ExpectedAnswer())
-					}
-				} else {
-					str += fmt.Sprintf("%s -> %s\n", strings.TrimSpace(question.Answer), question.ExpectedAnswer())
-				}
-			}
+	correct := s.Total - s.Mistakes - s.NearMisses - s.WrongArticles
+
+	// Near misses and wrong articles are not counted as correct either
+	performance := 0.0
+	if s.Total > 0 {
+		performance = float64(correct) / float64(s.Total) * 100
+	}
+
+	str := fmt.Sprintf("\nTotal: %d, Correct: %d, ", s.Total, correct)
+	if s.NearMisses > 0 {
+		str += fmt.Sprintf("Almost: %d, ", s.NearMisses)
+	}
+	if s.WrongArticles > 0 {
+		str += fmt.Sprintf("Wrong article: %d, ", s.WrongArticles)
+	}
+	str += fmt.Sprintf("Mistakes: %d, Performance: %.0f%%\n", s.Mistakes, performance)
+
+	for _, question := range s.Questions {
+		result := question.Check()
+		if result == EXACT {
+			continue
+		}
+
+		line := fmt.Sprintf("%s -> %s", question.GivenAnswer(), question.ExpectedAnswer())
+
+		if question.Type == ENGLISH_TO_FOREIGN && question.Word.Pronunciation != "" {
+			line += fmt.Sprintf(" [%s]", question.Word.Pronunciation)
 		}
+
+		switch result {
+		case ALMOST:
+			line += " (almost)"
+		case NORMALIZED:
+			line += " (normalized)"
+		case WRONG_ARTICLE:
+			line += " (wrong article)"
+		}
+
+		str += line + "\n"
 	}
 
 	return str
 }
 
+// Review records a single answer given during a quiz
+type Review struct {
+	Lang     string
+	Word     string
+	Time     time.Time
+	Type     int
+	Answer   string
+	Correct  bool
+	Duration time.Duration
+}
+
+func NewReview(question *Question) *Review {
+	answered := question.Answered
+	if answered.IsZero() {
+		answered = time.Now()
+	}
+
+	return &Review{
+		Lang:     question.Word.Lang,
+		Word:     question.Word.Word,
+		Time:     answered,
+		Type:     question.Type,
+		Answer:   strings.TrimSpace(question.Answer),
+		Correct:  question.IsCorrect(),
+		Duration: question.Duration,
+	}
+}
+
+type SearchResult struct {
+	Word *Word
+
+	// Fields is the list of fields matching the query
+	Fields []string
+}
+
 type Word struct {
-	Lang          string
-	Word          string
-	Meaning       string
-	Pronunciation string
-	Example       string
-	Tags          []string
-	Score         float64
+	Lang string `json:"lang"`
+	Word string `json:"word"`
+
+	// Article nouns are used with, as "das" for "Haus"
+	Article string `json:"article,omitempty"`
+
+	// Draft words were imported without a meaning, still to be filled in
+	Draft bool `json:"draft,omitempty"`
+
+	Meanings      []Meaning `json:"meanings"`
+	Pronunciation string    `json:"pronunciation,omitempty"`
+	Example       string    `json:"example,omitempty"`
+	Tags          []string  `json:"tags"`
+	Added         time.Time `json:"added"`
+	Score         float64   `json:"score"`
+
+	// Scheduling state
+	Ease        float64   `json:"ease"`
+	Interval    int       `json:"interval"`
+	Repetitions int       `json:"repetitions"`
+	Due         time.Time `json:"due"`
+	Reviewed    time.Time `json:"reviewed"`
+	Stability   float64   `json:"stability"`
+	Difficulty  float64   `json:"difficulty"`
+	Box         int       `json:"box"`
+}
+
+// Meaning shows every meaning of the word with its note
+func (w *Word) Meaning() string {
+	meanings := make([]string, len(w.Meanings))
+	for i, meaning := range w.Meanings {
+		meanings[i] = meaning.String()
+	}
+	return strings.Join(meanings, MEANING_SEPARATOR+" ")
+}
+
+// WithArticle returns the word preceded by its article, if any
+func (w *Word) WithArticle() string {
+	if w.Article == "" {
+		return w.Word
+	}
+	return w.Article + " " + w.Word
+}
+
+// Gender is the grammatical gender given by the word's article, empty for
+// words without article or languages whose articles are unknown
+func (w *Word) Gender() string {
+	return articles[strings.ToLower(w.Lang)][strings.ToLower(w.Article)]
 }
 
 func (w *Word) Level() string {
@@ -115,24 +596,135 @@ func (w *Word) Level() string {
 	return "Easy"
 }
 
+// MEANING_SEPARATOR separates the meanings of a word written as text
+const MEANING_SEPARATOR = ";"
+
+// Meaning is one of the translations of a word, the note telling the
+// context it is used in, as "Husband (spouse)"
+type Meaning struct {
+	Text string `json:"text"`
+	Note string `json:"note,omitempty"`
+}
+
+// ParseMeaning reads a meaning followed by its note in parentheses
+func ParseMeaning(text string) Meaning {
+	text = strings.TrimSpace(text)
+
+	if strings.HasSuffix(text, ")") {
+		if start := strings.LastIndex(text, "("); start > 0 {
+			return Meaning{
+				Text: strings.TrimSpace(text[:start]),
+				Note: strings.TrimSpace(text[start+1 : len(text)-1]),
+			}
+		}
+	}
+
+	return Meaning{Text: text}
+}
+
+// ParseMeanings reads meanings separated by MEANING_SEPARATOR, as in
+// "Man; Husband (spouse)"
+func ParseMeanings(text string) []Meaning {
+	meanings := make([]Meaning, 0)
+	for _, part := range strings.Split(text, MEANING_SEPARATOR) {
+		if meaning := ParseMeaning(part); meaning.Text != "" {
+			meanings = append(meanings, meaning)
+		}
+	}
+	return meanings
+}
+
+func (m Meaning) String() string {
+	if m.Note == "" {
+		return m.Text
+	}
+	return fmt.Sprintf("%s (%s)", m.Text, m.Note)
+}
+
+// Settings holds the per language preferences
+type Settings struct {
+	Lang      string
+	Scheduler string
+	Retention float64
+
+	// Leitner boxes, intervals in days default to doubling for each box
+	Boxes        int
+	BoxIntervals []int
+}
+
+// Intervals returns the review interval in days of each Leitner box
+func (s *Settings) Intervals() []int {
+	if len(s.BoxIntervals) > 0 {
+		return s.BoxIntervals
+	}
+	if s.Boxes > 0 {
+		return leitnerIntervals(s.Boxes)
+	}
+	return leitnerIntervals(DEFAULT_BOXES)
+}
+
+type QuizOptions struct {
+	// Scheduler overrides the language's scheduler for this quiz
+	Scheduler string
+
+	// Matcher grades the answers, DefaultMatcher when nil
+	Matcher *Matcher
+
+	// Strict only accepts answers typed with the language's special letters
+	Strict bool
+
+	// Articles requires, ignores or separately asks the article of nouns
+	Articles string
+
+	// Choices is the number of wrong choices offered along the right
+	// meaning, zero asking to type the answers instead
+	Choices int
+}
+
 type Service interface {
-	AddWord(lang, word, meaning, pronunciation, example string, tags []string) (*Word, error)
-	UpdateWord(lang, word, meaning, pronunciation, example string, tags []string) (*Word, error)
-	CreateQuiz(lang string, tags []string) ([]*Question, error)
+	// ForUser returns the same service for another user, sharing the
+	// words but keeping their own progress
+	ForUser(user string) (Service, error)
+
+	AddWord(word *Word) (*Word, error)
+	UpdateWord(word *Word) (*Word, error)
+	HasWord(lang, word string) (bool, error)
+	DeleteWord(lang, word string) error
+	ListWords(lang string, tags []string, level, sort string, limit int) ([]*Word, error)
+	SearchWords(lang, query string) ([]*SearchResult, error)
+	CreateQuiz(lang string, tags []string, options QuizOptions) ([]*Question, error)
 	SaveResult(summary *Summary) error
 	ImportWords(words []*Word) map[string]error
+	Settings(lang string) (*Settings, error)
+	SaveSettings(settings *Settings) error
 }
 
 type service struct {
 	repository WordRepository
+	scheduler  Scheduler
 }
 
 func NewService(repository WordRepository) *service {
-	return &service{repository}
+	return NewServiceWithScheduler(repository, NewSM2Scheduler())
 }
 
-func (s *service) AddWord(lang, word, meaning, pronunciation, example string, tags []string) (*Word, error) {
-	exists, err := s.repository.HasWord(lang, word)
+func NewServiceWithScheduler(repository WordRepository, scheduler Scheduler) *service {
+	return &service{repository, scheduler}
+}
+
+func (s *service) ForUser(user string) (Service, error) {
+	repository, err := s.repository.ForUser(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewServiceWithScheduler(repository, s.scheduler), nil
+}
+
+func (s *service) AddWord(word *Word) (*Word, error) {
+	word = withArticle(word)
+
+	exists, err := s.repository.HasWord(word.Lang, word.Word)
 	if err != nil {
 		return nil, err
 	}
@@ -141,11 +733,13 @@ func (s *service) AddWord(lang, word, meaning, pronunciation, example string, ta
 		return nil, ErrWordAlreadyRegistered
 	}
 
-	return s.repository.AddWord(lang, word, meaning, pronunciation, example, tags)
+	return s.repository.AddWord(word)
 }
 
-func (s *service) UpdateWord(lang, word, meaning, pronunciation, example string, tags []string) (*Word, error) {
-	exists, err := s.repository.HasWord(lang, word)
+func (s *service) UpdateWord(word *Word) (*Word, error) {
+	word = withArticle(word)
+
+	exists, err := s.repository.HasWord(word.Lang, word.Word)
 	if err != nil {
 		return nil, err
 	}
@@ -154,10 +748,99 @@ func (s *service) UpdateWord(lang, word, meaning, pronunciation, example string,
 		return nil, ErrWordNotRegistered
 	}
 
-	return s.repository.UpdateWord(lang, word, meaning, pronunciation, example, tags)
+	return s.repository.UpdateWord(word)
+}
+
+// withArticle returns a copy of the word with its article split off, so
+// "das Haus" is stored as "Haus" with the article "das". Words tagged as
+// phrases are kept as typed.
+func withArticle(word *Word) *Word {
+	w := *word
+	if w.Article == "" && !hasAnyTag(&w, []string{TAG_PHRASE}) {
+		w.Article, w.Word = splitArticle(w.Lang, w.Word, hasAnyTag(&w, []string{TAG_NOUN}))
+	}
+	return &w
+}
+
+// registeredWord finds how a word, written with or without its article,
+// is registered, trying it as typed first
+func (s *service) registeredWord(lang, word string) (string, bool, error) {
+	word = strings.TrimSpace(word)
+
+	exists, err := s.repository.HasWord(lang, word)
+	if err != nil || exists {
+		return word, exists, err
+	}
+
+	_, word = splitArticle(lang, word, true)
+	exists, err = s.repository.HasWord(lang, word)
+	return word, exists, err
+}
+
+// HasWord tells whether a word, written with or without its article, is
+// already registered
+func (s *service) HasWord(lang, word string) (bool, error) {
+	_, exists, err := s.registeredWord(lang, word)
+	return exists, err
+}
+
+func (s *service) DeleteWord(lang, word string) error {
+	word, exists, err := s.registeredWord(lang, word)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return ErrWordNotRegistered
+	}
+
+	return s.repository.DeleteWord(lang, word)
+}
+
+func (s *service) ListWords(lang string, tags []string, level, sort string, limit int) ([]*Word, error) {
+	switch level {
+	case "", LEVEL_HARD, LEVEL_MEDIUM, LEVEL_EASY:
+	default:
+		return nil, ErrUnknownLevel
+	}
+
+	switch sort {
+	case "", SORT_WORD, SORT_SCORE, SORT_ADDED:
+	default:
+		return nil, ErrUnknownSort
+	}
+
+	return s.repository.ListWords(lang, tags, level, sort, limit)
+}
+
+func (s *service) SearchWords(lang, query string) ([]*SearchResult, error) {
+	results, err := s.repository.SearchWords(lang, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNoWordsFound
+	}
+
+	return results, nil
 }
 
-func (s *service) CreateQuiz(lang string, tags []string) ([]*Question, error) {
+func (s *service) CreateQuiz(lang string, tags []string, options QuizOptions) ([]*Question, error) {
+	// Options are checked before reading words, so invalid ones are
+	// reported even when there is nothing to quiz
+	switch options.Articles {
+	case "", ARTICLES_IGNORE, ARTICLES_REQUIRE, ARTICLES_ASK:
+	default:
+		return nil, ErrUnknownArticles
+	}
+
+	if options.Scheduler != "" {
+		if _, err := s.schedulerFor(options.Scheduler, &Settings{Lang: lang}); err != nil {
+			return nil, err
+		}
+	}
+
 	words, err := s.repository.FindWords(lang, tags)
 	if err != nil {
 		return nil, err
@@ -167,29 +850,171 @@ func (s *service) CreateQuiz(lang string, tags []string) ([]*Question, error) {
 		return nil, ErrNoWordsFound
 	}
 
+	scheduler := options.Scheduler
+	if scheduler == "" {
+		settings, err := s.repository.FindSettings(lang)
+		if err != nil {
+			return nil, err
+		}
+		scheduler = settings.Scheduler
+	}
+
+	var candidates []*Word
+	if options.Choices > 0 {
+		candidates, err = s.repository.ListWords(lang, nil, "", SORT_WORD, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	questions := make([]*Question, 0)
 	for _, word := range words {
-		questions = append(questions, NewQuestion(word))
+		var question *Question
+
+		if options.Choices > 0 {
+			question = NewChoiceQuestion(word, distractors(word, candidates, options.Choices))
+		} else {
+			question = NewQuestion(word)
+		}
+
+		questions = append(questions, question)
+
+		if options.Articles == ARTICLES_ASK && word.Article != "" {
+			questions = append(questions, NewArticleQuestion(word))
+		}
+	}
+
+	for _, question := range questions {
+		question.Scheduler = scheduler
+		question.Matcher = options.Matcher
+		question.Strict = options.Strict
+		question.Articles = options.Articles
 	}
 
 	return questions, nil
 }
 
+// distractors picks the meanings of up to n other words to offer as wrong
+// choices, preferring words sharing a tag with the asked one
+func distractors(word *Word, candidates []*Word, n int) []string {
+	related := make([]string, 0)
+	unrelated := make([]string, 0)
+	seen := map[string]bool{strings.ToLower(word.Meaning()): true}
+
+	for _, i := range rand.Perm(len(candidates)) {
+		candidate := candidates[i]
+		meaning := strings.ToLower(candidate.Meaning())
+
+		if candidate.Word == word.Word || candidate.Draft || seen[meaning] {
+			continue
+		}
+		seen[meaning] = true
+
+		if hasAnyTag(candidate, word.Tags) {
+			related = append(related, candidate.Meaning())
+		} else {
+			unrelated = append(unrelated, candidate.Meaning())
+		}
+	}
+
+	choices := append(related, unrelated...)
+	if len(choices) > n {
+		choices = choices[:n]
+	}
+
+	return choices
+}
+
 func (s *service) SaveResult(summary *Summary) error {
+	now := time.Now()
+	settings := make(map[string]*Settings)
+
+	for _, question := range summary.Questions {
+		lang := question.Word.Lang
+
+		if _, ok := settings[lang]; !ok {
+			found, err := s.repository.FindSettings(lang)
+			if err != nil {
+				return err
+			}
+			settings[lang] = found
+		}
+
+		// The word is scheduled by the question asking it, not its article
+		if question.Type == ARTICLE {
+			continue
+		}
+
+		name := question.Scheduler
+		if name == "" {
+			name = settings[lang].Scheduler
+		}
+
+		scheduler, err := s.schedulerFor(name, settings[lang])
+		if err != nil {
+			return err
+		}
+
+		scheduler.Schedule(question.Word, rate(question), now)
+	}
+
 	return s.repository.SaveResult(summary)
 }
 
+// schedulerFor returns the named scheduler configured with the language's
+// settings, falling back to the service's scheduler when none is named
+func (s *service) schedulerFor(name string, settings *Settings) (Scheduler, error) {
+	switch name {
+	case "":
+		return s.scheduler, nil
+	case SM2:
+		return NewSM2Scheduler(), nil
+	case FSRS:
+		return NewFSRSScheduler(settings.Retention), nil
+	case LEITNER:
+		return NewLeitnerScheduler(settings.Intervals()), nil
+	}
+	return nil, ErrUnknownScheduler
+}
+
+func (s *service) Settings(lang string) (*Settings, error) {
+	return s.repository.FindSettings(lang)
+}
+
+func (s *service) SaveSettings(settings *Settings) error {
+	if _, err := s.schedulerFor(settings.Scheduler, settings); err != nil {
+		return err
+	}
+
+	if settings.Retention <= 0 || settings.Retention >= 1 {
+		return ErrInvalidRetention
+	}
+
+	if settings.Boxes < 0 || (len(settings.BoxIntervals) > 0 && settings.Boxes != len(settings.BoxIntervals)) {
+		return ErrInvalidBoxes
+	}
+
+	for _, interval := range settings.BoxIntervals {
+		if interval < 1 {
+			return ErrInvalidBoxes
+		}
+	}
+
+	return s.repository.SaveSettings(settings)
+}
+
 func (s *service) ImportWords(words []*Word) map[string]error {
 	failedWords := make(map[string]error)
 
 	for _, word := range words {
+		word = withArticle(word)
 		exists, err := s.repository.HasWord(word.Lang, word.Word)
 
 		if err == nil {
 			if exists {
-				_, err = s.UpdateWord(word.Lang, word.Word, word.Meaning, word.Pronunciation, word.Example, word.Tags)
+				_, err = s.UpdateWord(word)
 			} else {
-				_, err = s.AddWord(word.Lang, word.Word, word.Meaning, word.Pronunciation, word.Example, word.Tags)
+				_, err = s.AddWord(word)
 			}
 		}
 
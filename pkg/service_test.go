@@ -1,6 +1,7 @@
 package pkg_test
 
 import (
+	"strconv"
 	"testing"
 
 	"example.com/gocab/pkg"
@@ -11,8 +12,8 @@ func TestAdd(t *testing.T) {
 		repository := pkg.NewInMemoryRepository()
 		service := pkg.NewService(repository)
 
-		service.AddWord("German", "Haus", "house", "", "Dein Haus ist sauber", []string{"noun"})
-		service.AddWord("Spanish", "hola", "hello", "", "Hola, hombre", []string{"greeting"})
+		service.AddWord(&pkg.Word{Lang: "German", Word: "Haus", Meanings: pkg.ParseMeanings("house"), Example: "Dein Haus ist sauber", Tags: []string{"noun"}})
+		service.AddWord(&pkg.Word{Lang: "Spanish", Word: "hola", Meanings: pkg.ParseMeanings("hello"), Example: "Hola, hombre", Tags: []string{"greeting"}})
 
 		if exists, _ := repository.HasWord("German", "Haus"); !exists {
 			t.Error("should have word \"Haus\" in German")
@@ -25,11 +26,11 @@ func TestAdd(t *testing.T) {
 
 	t.Run("repeated", func(t *testing.T) {
 		repository := pkg.NewInMemoryRepository()
-		repository.AddWord("German", "Haus", "", "", "", nil)
+		repository.AddWord(&pkg.Word{Lang: "German", Word: "Haus"})
 
 		service := pkg.NewService(repository)
 
-		_, err := service.AddWord("German", "Haus", "house", "", "Dein Haus ist sauber", []string{"noun"})
+		_, err := service.AddWord(&pkg.Word{Lang: "German", Word: "Haus", Meanings: pkg.ParseMeanings("house"), Example: "Dein Haus ist sauber", Tags: []string{"noun"}})
 		if err != pkg.ErrWordAlreadyRegistered {
 			t.Errorf("expected error %v, got %v", pkg.ErrWordAlreadyRegistered, err)
 		}
@@ -39,7 +40,7 @@ func TestAdd(t *testing.T) {
 		repository := pkg.NewInMemoryRepository()
 		service := pkg.NewService(repository)
 
-		if _, err := service.UpdateWord("german", "Haus", "House; Home", "", "Ich habe ein Haus", []string{"nouns"}); err != pkg.ErrWordNotRegistered {
+		if _, err := service.UpdateWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House; Home"), Example: "Ich habe ein Haus", Tags: []string{"nouns"}}); err != pkg.ErrWordNotRegistered {
 			t.Errorf("expected %v, got %v", pkg.ErrWordNotRegistered, err)
 		}
 	})
@@ -48,15 +49,15 @@ func TestAdd(t *testing.T) {
 		repository := pkg.NewInMemoryRepository()
 		service := pkg.NewService(repository)
 
-		repository.AddWord("german", "Haus", "House", "", "Mein Haus ist blau", []string{"nouns"})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Example: "Mein Haus ist blau", Tags: []string{"nouns"}})
 
-		word, err := service.UpdateWord("german", "Haus", "House; Home", "", "Ich habe ein Haus", []string{"nouns"})
+		word, err := service.UpdateWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House; Home"), Example: "Ich habe ein Haus", Tags: []string{"nouns"}})
 		if err != nil {
 			t.Errorf("expected no error, got %v", err)
 		}
 
-		if word.Meaning != "House; Home" {
-			t.Errorf("should have updated meaning, got %v", word.Meaning)
+		if word.Meaning() != "House; Home" {
+			t.Errorf("should have updated meaning, got %v", word.Meaning())
 		}
 
 		if word.Example != "Ich habe ein Haus" {
@@ -68,7 +69,7 @@ func TestAdd(t *testing.T) {
 		repository := pkg.NewInMemoryRepository()
 		service := pkg.NewService(repository)
 
-		words, err := service.CreateQuiz("stormtrooper", []string{"pronoun"})
+		words, err := service.CreateQuiz("stormtrooper", []string{"pronoun"}, pkg.QuizOptions{})
 
 		if err != pkg.ErrNoWordsFound {
 			t.Errorf("should get error %v, got %v", pkg.ErrNoWordsFound, err)
@@ -83,12 +84,12 @@ func TestAdd(t *testing.T) {
 		repository := pkg.NewInMemoryRepository()
 		service := pkg.NewService(repository)
 
-		repository.AddWord("german", "Er", "He", "", "Er ist mein Mann", []string{"pronoun"})
-		repository.AddWord("german", "Mann", "Man; Husband", "", "Mein Mann ist stark", []string{"noun"})
-		repository.AddWord("german", "Frau", "Woman; Wife", "", "Mein Frau ist schon", []string{"noun"})
-		repository.AddWord("german", "Stark", "Strong", "", "Mein Mann ist stark", []string{"adjective"})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Er", Meanings: pkg.ParseMeanings("He"), Example: "Er ist mein Mann", Tags: []string{"pronoun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man; Husband"), Example: "Mein Mann ist stark", Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Frau", Meanings: pkg.ParseMeanings("Woman; Wife"), Example: "Mein Frau ist schon", Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Stark", Meanings: pkg.ParseMeanings("Strong"), Example: "Mein Mann ist stark", Tags: []string{"adjective"}})
 
-		words, err := service.CreateQuiz("german", []string{"noun", "pronoun"})
+		words, err := service.CreateQuiz("german", []string{"noun", "pronoun"}, pkg.QuizOptions{})
 
 		if err != nil {
 			t.Errorf("should not get error, got %v", err)
@@ -103,12 +104,12 @@ func TestAdd(t *testing.T) {
 		repository := pkg.NewInMemoryRepository()
 		service := pkg.NewService(repository)
 
-		repository.AddWord("german", "Er", "He", "", "Er ist mein Mann", []string{"pronoun"})
-		repository.AddWord("german", "Mann", "Man; Husband", "", "Mein Mann ist stark", []string{"noun"})
-		repository.AddWord("german", "Frau", "Woman; Wife", "", "Mein Frau ist schon", []string{"noun"})
-		repository.AddWord("german", "Stark", "Strong", "", "Mein Mann ist stark", []string{"adjective"})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Er", Meanings: pkg.ParseMeanings("He"), Example: "Er ist mein Mann", Tags: []string{"pronoun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man; Husband"), Example: "Mein Mann ist stark", Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Frau", Meanings: pkg.ParseMeanings("Woman; Wife"), Example: "Mein Frau ist schon", Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Stark", Meanings: pkg.ParseMeanings("Strong"), Example: "Mein Mann ist stark", Tags: []string{"adjective"}})
 
-		words, err := service.CreateQuiz("german", []string{})
+		words, err := service.CreateQuiz("german", []string{}, pkg.QuizOptions{})
 
 		if err != nil {
 			t.Errorf("should not get error, got %v", err)
@@ -124,10 +125,10 @@ func TestAdd(t *testing.T) {
 		service := pkg.NewService(repository)
 
 		failed := service.ImportWords([]*pkg.Word{
-			{"german", "Hallo", "Hello", "", "Hallo, wie gehts", nil, 0},
-			{"german", "Prost", "Cheers", "", "Prost!", nil, 0},
-			{"german", "Haus", "House", "", "Mein Haus ist weit weg", nil, 0},
-			//{"spanish", "Hombre", "Man", "", "Un belo hombre", nil, 0},
+			{Lang: "german", Word: "Hallo", Meanings: pkg.ParseMeanings("Hello"), Example: "Hallo, wie gehts"},
+			{Lang: "german", Word: "Prost", Meanings: pkg.ParseMeanings("Cheers"), Example: "Prost!"},
+			{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Example: "Mein Haus ist weit weg"},
+			//{Lang: "spanish", Word: "Hombre", Meanings: pkg.ParseMeanings("Man"), Example: "Un belo hombre"},
 		})
 
 		if len(failed) != 0 {
@@ -149,10 +150,10 @@ func TestAdd(t *testing.T) {
 		service := pkg.NewService(repository)
 
 		failed := service.ImportWords([]*pkg.Word{
-			{"german", "Hallo", "Hello", "", "Hallo, wie gehts", nil, 0},
-			{"german", "Prost", "Cheers", "", "Prost!", nil, 0},
-			{"german", "Haus", "House", "", "Mein Haus ist weit weg", nil, 0},
-			{"spanish", "Hombre", "Man", "", "Un belo hombre", nil, 0},
+			{Lang: "german", Word: "Hallo", Meanings: pkg.ParseMeanings("Hello"), Example: "Hallo, wie gehts"},
+			{Lang: "german", Word: "Prost", Meanings: pkg.ParseMeanings("Cheers"), Example: "Prost!"},
+			{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Example: "Mein Haus ist weit weg"},
+			{Lang: "spanish", Word: "Hombre", Meanings: pkg.ParseMeanings("Man"), Example: "Un belo hombre"},
 		})
 
 		if len(failed) != 0 {
@@ -182,16 +183,16 @@ func TestAdd(t *testing.T) {
 		repository := pkg.NewInMemoryRepository()
 		service := pkg.NewService(repository)
 
-		repository.AddWord("german", "Er", "He", "", "Er ist mein Mann", []string{"pronoun"})
-		repository.AddWord("german", "Mann", "Man; Husband", "", "Mein Mann ist stark", []string{"noun"})
-		repository.AddWord("german", "Frau", "Woman; Wife", "", "Mein Frau ist schon", []string{"noun"})
-		repository.AddWord("german", "Stark", "Strong", "", "Mein Mann ist stark", []string{"adjective"})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Er", Meanings: pkg.ParseMeanings("He"), Example: "Er ist mein Mann", Tags: []string{"pronoun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man; Husband"), Example: "Mein Mann ist stark", Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Frau", Meanings: pkg.ParseMeanings("Woman; Wife"), Example: "Mein Frau ist schon", Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Stark", Meanings: pkg.ParseMeanings("Strong"), Example: "Mein Mann ist stark", Tags: []string{"adjective"}})
 
 		failed := service.ImportWords([]*pkg.Word{
-			{"german", "Er", "Hello", "", "Hallo, wie gehts", nil, 0},
-			{"german", "Mann", "Cheers", "", "Prost!", nil, 0},
-			{"german", "Frau", "House", "", "Mein Haus ist weit weg", nil, 0},
-			{"german", "Stark", "Man", "", "Un belo hombre", nil, 0},
+			{Lang: "german", Word: "Er", Meanings: pkg.ParseMeanings("Hello"), Example: "Hallo, wie gehts"},
+			{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Cheers"), Example: "Prost!"},
+			{Lang: "german", Word: "Frau", Meanings: pkg.ParseMeanings("House"), Example: "Mein Haus ist weit weg"},
+			{Lang: "german", Word: "Stark", Meanings: pkg.ParseMeanings("Man"), Example: "Un belo hombre"},
 		})
 
 		if len(failed) != 0 {
@@ -211,9 +212,347 @@ func TestAdd(t *testing.T) {
 		}
 
 		for _, word := range words {
-			if expected[word.Word] != word.Meaning {
-				t.Errorf("expected meaning %s for word %s, got %s", expected[word.Word], word.Word, word.Meaning)
+			if expected[word.Word] != word.Meaning() {
+				t.Errorf("expected meaning %s for word %s, got %s", expected[word.Word], word.Word, word.Meaning())
 			}
 		}
 	})
 }
+
+func TestSettings(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		service := pkg.NewService(pkg.NewInMemoryRepository())
+
+		settings, err := service.Settings("german")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if settings.Scheduler != "" || settings.Retention != pkg.DEFAULT_RETENTION {
+			t.Errorf("expected default settings, got %v", settings)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		service := pkg.NewService(pkg.NewInMemoryRepository())
+
+		err := service.SaveSettings(&pkg.Settings{Lang: "german", Scheduler: "anki", Retention: 0.9})
+		if err != pkg.ErrUnknownScheduler {
+			t.Errorf("expected error %v, got %v", pkg.ErrUnknownScheduler, err)
+		}
+
+		if _, err := service.CreateQuiz("german", nil, pkg.QuizOptions{Scheduler: "anki"}); err != pkg.ErrUnknownScheduler {
+			t.Errorf("expected error %v without words, got %v", pkg.ErrUnknownScheduler, err)
+		}
+
+		err = service.SaveSettings(&pkg.Settings{Lang: "german", Scheduler: pkg.FSRS, Retention: 1.5})
+		if err != pkg.ErrInvalidRetention {
+			t.Errorf("expected error %v, got %v", pkg.ErrInvalidRetention, err)
+		}
+	})
+
+	t.Run("scheduler per language", func(t *testing.T) {
+		repository := pkg.NewInMemoryRepository()
+		service := pkg.NewService(repository)
+
+		if err := service.SaveSettings(&pkg.Settings{Lang: "german", Scheduler: pkg.FSRS, Retention: 0.9}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House")})
+		repository.AddWord(&pkg.Word{Lang: "spanish", Word: "Casa", Meanings: pkg.ParseMeanings("House")})
+
+		german, _ := repository.FindWords("german", nil)
+		spanish, _ := repository.FindWords("spanish", nil)
+
+		summary := &pkg.Summary{Total: 2}
+		summary.Correct(&pkg.Question{Type: pkg.FOREIGN_TO_ENGLISH, Word: german[0], Answer: "House"})
+		summary.Correct(&pkg.Question{Type: pkg.FOREIGN_TO_ENGLISH, Word: spanish[0], Answer: "House"})
+
+		if err := service.SaveResult(summary); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		german, _ = repository.FindWords("german", nil)
+		if german[0].Stability == 0 {
+			t.Error("expected german word to be scheduled with fsrs")
+		}
+
+		spanish, _ = repository.FindWords("spanish", nil)
+		if spanish[0].Stability != 0 || spanish[0].Repetitions != 1 {
+			t.Error("expected spanish word to be scheduled with sm2")
+		}
+	})
+}
+
+func TestSummaryPerformance(t *testing.T) {
+	summary := &pkg.Summary{Total: 4, NearMisses: 1, WrongArticles: 1}
+
+	expected := "\nTotal: 4, Correct: 2, Almost: 1, Wrong article: 1, Mistakes: 0, Performance: 50%\n"
+	if summary.String() != expected {
+		t.Errorf("expected %q, got %q", expected, summary.String())
+	}
+}
+
+func TestReviews(t *testing.T) {
+	repository := pkg.NewInMemoryRepository()
+	service := pkg.NewService(repository)
+
+	repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House")})
+	words, _ := repository.FindWords("german", nil)
+
+	for _, answer := range []string{"Mouse", "House"} {
+		summary := &pkg.Summary{Total: 1}
+		summary.Correct(&pkg.Question{Type: pkg.FOREIGN_TO_ENGLISH, Word: words[0], Answer: answer})
+
+		if err := service.SaveResult(summary); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	reviews, err := repository.FindReviews("german", "Haus")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(reviews) != 2 {
+		t.Fatalf("expected %d reviews, got %d", 2, len(reviews))
+	}
+
+	if !reviews[0].Correct || reviews[1].Correct || reviews[1].Answer != "Mouse" {
+		t.Errorf("expected most recent review first, got %v and %v", reviews[0], reviews[1])
+	}
+}
+
+func TestMultipleChoice(t *testing.T) {
+	t.Run("choices", func(t *testing.T) {
+		repository := pkg.NewInMemoryRepository()
+		service := pkg.NewService(repository)
+
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House"), Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man"), Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Frau", Meanings: pkg.ParseMeanings("Woman"), Tags: []string{"noun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Er", Meanings: pkg.ParseMeanings("He"), Tags: []string{"pronoun"}})
+		repository.AddWord(&pkg.Word{Lang: "german", Word: "Sie", Meanings: pkg.ParseMeanings("She"), Tags: []string{"pronoun"}})
+
+		questions, err := service.CreateQuiz("german", []string{"noun"}, pkg.QuizOptions{Choices: 2})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		for _, question := range questions {
+			if question.Type != pkg.MULTIPLE_CHOICE {
+				t.Fatalf("expected multiple choice question, got type %d", question.Type)
+			}
+
+			if len(question.Choices) != 3 {
+				t.Fatalf("expected %d choices, got %v", 3, question.Choices)
+			}
+
+			for i, choice := range question.Choices {
+				if choice == question.Word.Meaning() {
+					question.Answer = strconv.Itoa(i+1) + "\n"
+				}
+
+				// Other nouns are preferred over pronouns
+				if choice == "He" || choice == "She" {
+					t.Errorf("expected nouns as choices for %s, got %v", question.Word.Word, question.Choices)
+				}
+			}
+
+			if !question.IsCorrect() {
+				t.Errorf("expected answer %q to be correct for choices %v", question.Answer, question.Choices)
+			}
+		}
+	})
+
+	t.Run("answer", func(t *testing.T) {
+		word := &pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House")}
+		question := &pkg.Question{Type: pkg.MULTIPLE_CHOICE, Word: word, Choices: []string{"Man", "House"}}
+
+		for answer, correct := range map[string]bool{"2": true, " 2\n": true, "1": false, "3": false, "House": false} {
+			question.Answer = answer
+			if question.IsCorrect() != correct {
+				t.Errorf("expected answer %q to be correct: %v", answer, correct)
+			}
+		}
+
+		question.Answer = "1"
+		if question.GivenAnswer() != "Man" {
+			t.Errorf("expected given answer %s, got %s", "Man", question.GivenAnswer())
+		}
+	})
+}
+
+func TestClozeQuestion(t *testing.T) {
+	cases := []struct {
+		word, example, sentence string
+		blanks                  []string
+	}{
+		{"Haus", "Mein Haus ist weit weg", "Mein ___ ist weit weg", []string{"Haus"}},
+		{"stark", "Mein Mann ist Stark.", "Mein Mann ist ___.", []string{"Stark"}},
+		{"casa", "Mi casa es tu casa", "Mi ___ es tu ___", []string{"casa"}},
+		{"gehen", "Du gehst nach Hause", "Du ___ nach Hause", []string{"gehst"}},
+		{"Haus", "Das Häuschen", "", nil},
+		{"Haus", "Die Haut am Hauptbahnhof", "", nil},
+		{"Haus", "Die Haut des Hauses", "Die Haut des ___", []string{"Hauses"}},
+		{"machen", "Wir machten es", "Wir ___ es", []string{"machten"}},
+		{"Er", "Der Mann", "", nil},
+		{"guten Morgen", "Guten Morgen, Frau Müller!", "___, Frau Müller!", []string{"Guten Morgen"}},
+	}
+
+	for _, c := range cases {
+		word := &pkg.Word{Lang: "german", Word: c.word, Meanings: pkg.ParseMeanings("meaning"), Example: c.example}
+		question, ok := pkg.NewClozeQuestion(word)
+
+		if c.blanks == nil {
+			if ok {
+				t.Errorf("expected no cloze for %s in %q, got %q", c.word, c.example, question.Sentence)
+			}
+			continue
+		}
+
+		if !ok {
+			t.Errorf("expected cloze for %s in %q", c.word, c.example)
+			continue
+		}
+
+		if question.Sentence != c.sentence {
+			t.Errorf("expected sentence %q, got %q", c.sentence, question.Sentence)
+		}
+
+		if len(question.Blanks) != len(c.blanks) || question.Blanks[0] != c.blanks[0] {
+			t.Errorf("expected blanks %v, got %v", c.blanks, question.Blanks)
+		}
+	}
+
+	t.Run("answer", func(t *testing.T) {
+		word := &pkg.Word{Lang: "german", Word: "gehen", Meanings: pkg.ParseMeanings("to go"), Example: "Du gehst nach Hause"}
+		question, _ := pkg.NewClozeQuestion(word)
+
+		if question.Text() != "[Hard] Fill in the blank (to go): Du ___ nach Hause\n" {
+			t.Errorf("unexpected text %q", question.Text())
+		}
+
+		for answer, correct := range map[string]bool{"gehst\n": true, "Gehen": true, "geht": false} {
+			question.Answer = answer
+			if question.IsCorrect() != correct {
+				t.Errorf("expected answer %q to be correct: %v", answer, correct)
+			}
+		}
+	})
+}
+
+func TestMeanings(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
+		meanings := pkg.ParseMeanings("Man;  Husband (spouse) ; ")
+
+		expected := []pkg.Meaning{{Text: "Man"}, {Text: "Husband", Note: "spouse"}}
+		if len(meanings) != len(expected) {
+			t.Fatalf("expected %d meanings, got %v", len(expected), meanings)
+		}
+
+		for i := range expected {
+			if meanings[i] != expected[i] {
+				t.Errorf("expected %v, got %v", expected[i], meanings[i])
+			}
+		}
+
+		word := &pkg.Word{Meanings: meanings}
+		if word.Meaning() != "Man; Husband (spouse)" {
+			t.Errorf("expected Man; Husband (spouse), got %s", word.Meaning())
+		}
+	})
+
+	t.Run("any meaning", func(t *testing.T) {
+		word := &pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man; Husband (spouse)")}
+
+		for _, answer := range []string{"man", "Husband", "Husband (spouse)"} {
+			question := &pkg.Question{Type: pkg.FOREIGN_TO_ENGLISH, Word: word, Answer: answer}
+			if result := question.Check(); result != pkg.EXACT {
+				t.Errorf("expected %q to be exact, got %v", answer, result)
+			}
+		}
+
+		question := &pkg.Question{Type: pkg.FOREIGN_TO_ENGLISH, Word: word, Answer: "spouse"}
+		if question.IsCorrect() {
+			t.Error("expected the note not to be accepted as a meaning")
+		}
+	})
+}
+
+func TestDrafts(t *testing.T) {
+	repository := pkg.NewInMemoryRepository()
+	service := pkg.NewService(repository)
+
+	service.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House")})
+	service.AddWord(&pkg.Word{Lang: "german", Word: "Mann", Meanings: pkg.ParseMeanings("Man")})
+	service.AddWord(&pkg.Word{Lang: "german", Word: "verhaften", Draft: true})
+
+	questions, err := service.CreateQuiz("german", nil, pkg.QuizOptions{Choices: 3})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(questions) != 2 {
+		t.Fatalf("expected 2 questions, got %d", len(questions))
+	}
+
+	for _, question := range questions {
+		if question.Word.Draft {
+			t.Errorf("expected drafts not to be asked, got %s", question.Word.Word)
+		}
+
+		if len(question.Choices) != 2 {
+			t.Errorf("expected drafts not to be offered as choices, got %v", question.Choices)
+		}
+	}
+
+	service.UpdateWord(&pkg.Word{Lang: "german", Word: "Haus", Draft: true})
+	service.UpdateWord(&pkg.Word{Lang: "german", Word: "Mann", Draft: true})
+
+	if _, err := service.CreateQuiz("german", nil, pkg.QuizOptions{}); err != pkg.ErrNoWordsFound {
+		t.Errorf("expected error %v, got %v", pkg.ErrNoWordsFound, err)
+	}
+}
+
+func TestDraftsQuizSize(t *testing.T) {
+	repositories := map[string]pkg.WordRepository{
+		"memory": pkg.NewInMemoryRepository(),
+		"sqlite": newSqliteRepository(t),
+	}
+
+	for name, repository := range repositories {
+		t.Run(name, func(t *testing.T) {
+			service := pkg.NewService(repository)
+
+			// A reviewed word is due after the drafts, which are due right away
+			repository.AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House")})
+			words, _ := repository.FindWords("german", nil)
+
+			summary := &pkg.Summary{Total: 1}
+			summary.Correct(&pkg.Question{Type: pkg.FOREIGN_TO_ENGLISH, Word: words[0], Answer: "House"})
+
+			if err := service.SaveResult(summary); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			for i := 0; i < pkg.QUIZ_SIZE+5; i++ {
+				repository.AddWord(&pkg.Word{Lang: "german", Word: "Entwurf" + strconv.Itoa(i), Draft: true})
+			}
+
+			questions, err := service.CreateQuiz("german", nil, pkg.QuizOptions{})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if len(questions) != 1 || questions[0].Word.Word != "Haus" {
+				t.Errorf("expected Haus to be asked, got %d question(s)", len(questions))
+			}
+		})
+	}
+}
+
+func TestUsers(t *testing.T) {
+	testUsers(t, pkg.NewInMemoryRepository())
+}
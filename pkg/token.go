@@ -0,0 +1,153 @@
+package pkg
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTokenNotFound = errors.New("token not found")
+	ErrUnknownScope  = errors.New("unknown token scope")
+	ErrUnauthorized  = errors.New("missing or invalid token")
+	ErrReadOnly      = errors.New("token is read-only")
+)
+
+// Scopes of API tokens, read-only tokens being meant for dashboards
+const SCOPE_READ = "read"
+const SCOPE_WRITE = "write"
+
+// TOKEN_SIZE is the number of random bytes of a token
+const TOKEN_SIZE = 32
+
+// Token grants access to the API on behalf of a user. Only the hash of the
+// token is stored, the token itself being shown once when created.
+type Token struct {
+	ID      int64
+	User    string
+	Name    string
+	Scope   string
+	Hash    string
+	Created time.Time
+}
+
+type TokenRepository interface {
+	AddToken(token *Token) (*Token, error)
+
+	// FindToken returns the token of the given hash, or ErrTokenNotFound
+	FindToken(hash string) (*Token, error)
+
+	// ListTokens returns the tokens of a user, or of every user when user
+	// is empty, oldest first
+	ListTokens(user string) ([]*Token, error)
+	DeleteToken(id int64) error
+}
+
+// NewToken generates a token for a user, returning it along with what is
+// stored of it
+func NewToken(user, name, scope string) (string, *Token, error) {
+	if user == "" {
+		return "", nil, ErrInvalidUser
+	}
+
+	if scope != SCOPE_READ && scope != SCOPE_WRITE {
+		return "", nil, ErrUnknownScope
+	}
+
+	secret := make([]byte, TOKEN_SIZE)
+	if _, err := rand.Read(secret); err != nil {
+		return "", nil, err
+	}
+
+	text := hex.EncodeToString(secret)
+
+	return text, &Token{User: user, Name: name, Scope: scope, Hash: HashToken(text), Created: time.Now()}, nil
+}
+
+// HashToken hashes a token the way it is stored
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// authenticate finds the service of the user whose token is given as an
+// Authorization header, read-only tokens only reading through it. Calls
+// are made for the service's user when tokens is nil.
+func authenticate(service Service, tokens TokenRepository, authorization string) (Service, string, error) {
+	if tokens == nil {
+		return service, "", nil
+	}
+
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return nil, "", ErrUnauthorized
+	}
+
+	token, err := tokens.FindToken(HashToken(strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))))
+	if errors.Is(err, ErrTokenNotFound) {
+		return nil, "", ErrUnauthorized
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	service, err = service.ForUser(token.User)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if token.Scope != SCOPE_WRITE {
+		service = NewReadOnlyService(service)
+	}
+
+	return service, token.User, nil
+}
+
+// readOnlyService lets read-only tokens call the service, rejecting every
+// call that would change words, progress or settings
+type readOnlyService struct {
+	Service
+}
+
+func NewReadOnlyService(service Service) Service {
+	return &readOnlyService{service}
+}
+
+func (s *readOnlyService) ForUser(user string) (Service, error) {
+	service, err := s.Service.ForUser(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReadOnlyService(service), nil
+}
+
+func (s *readOnlyService) AddWord(word *Word) (*Word, error) {
+	return nil, ErrReadOnly
+}
+
+func (s *readOnlyService) UpdateWord(word *Word) (*Word, error) {
+	return nil, ErrReadOnly
+}
+
+func (s *readOnlyService) DeleteWord(lang, word string) error {
+	return ErrReadOnly
+}
+
+func (s *readOnlyService) SaveResult(summary *Summary) error {
+	return ErrReadOnly
+}
+
+func (s *readOnlyService) ImportWords(words []*Word) map[string]error {
+	failedWords := make(map[string]error)
+	for _, word := range words {
+		failedWords[withArticle(word).Word] = ErrReadOnly
+	}
+	return failedWords
+}
+
+func (s *readOnlyService) SaveSettings(settings *Settings) error {
+	return ErrReadOnly
+}
@@ -0,0 +1,56 @@
+package pkg_test
+
+import (
+	"testing"
+
+	"example.com/gocab/pkg"
+)
+
+func TestNewToken(t *testing.T) {
+	secret, token, err := pkg.NewToken("alice", "dashboard", pkg.SCOPE_READ)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(secret) != 2*pkg.TOKEN_SIZE {
+		t.Errorf("expected %d characters, got %d", 2*pkg.TOKEN_SIZE, len(secret))
+	}
+
+	if token.Hash == secret || token.Hash != pkg.HashToken(secret) {
+		t.Errorf("expected the hash of the token to be stored, got %s", token.Hash)
+	}
+
+	if _, _, err := pkg.NewToken("alice", "", "admin"); err != pkg.ErrUnknownScope {
+		t.Errorf("expected error %v, got %v", pkg.ErrUnknownScope, err)
+	}
+
+	if _, _, err := pkg.NewToken("", "", pkg.SCOPE_WRITE); err != pkg.ErrInvalidUser {
+		t.Errorf("expected error %v, got %v", pkg.ErrInvalidUser, err)
+	}
+}
+
+func TestReadOnlyService(t *testing.T) {
+	repository := pkg.NewInMemoryRepository()
+	pkg.NewService(repository).AddWord(&pkg.Word{Lang: "german", Word: "Haus", Meanings: pkg.ParseMeanings("House")})
+
+	service, err := pkg.NewReadOnlyService(pkg.NewService(repository)).ForUser("alice")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if words, _ := service.ListWords("german", nil, "", "", 0); len(words) != 1 {
+		t.Errorf("expected %d word, got %d", 1, len(words))
+	}
+
+	if _, err := service.AddWord(&pkg.Word{Lang: "german", Word: "Mann"}); err != pkg.ErrReadOnly {
+		t.Errorf("expected error %v, got %v", pkg.ErrReadOnly, err)
+	}
+
+	if err := service.DeleteWord("german", "Haus"); err != pkg.ErrReadOnly {
+		t.Errorf("expected error %v, got %v", pkg.ErrReadOnly, err)
+	}
+
+	if failed := service.ImportWords([]*pkg.Word{{Lang: "german", Word: "der Mann"}}); failed["Mann"] != pkg.ErrReadOnly {
+		t.Errorf("expected Mann to be rejected, got %v", failed)
+	}
+}
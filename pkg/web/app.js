@@ -0,0 +1,171 @@
+"use strict";
+
+// Takes quizzes through the json api, one question at a time as the quiz
+// command does, showing how each answer was graded right away
+
+const $ = (id) => document.getElementById(id);
+
+let quiz = null;
+let position = 0;
+
+async function api(method, path, body) {
+  const headers = { "Content-Type": "application/json" };
+  const token = localStorage.getItem("token");
+
+  // Servers started without --no-auth require a token
+  if (token) {
+    headers.Authorization = `Bearer ${token}`;
+  }
+
+  const response = await fetch(path, {
+    method,
+    headers,
+    body: JSON.stringify(body),
+  });
+
+  const result = await response.json();
+  if (!response.ok) {
+    throw new Error(result.error || response.statusText);
+  }
+
+  return result;
+}
+
+function showError(error) {
+  $("error").textContent = error ? error.message : "";
+  $("error").hidden = !error;
+}
+
+function show(section) {
+  for (const id of ["start", "quiz", "summary"]) {
+    $(id).hidden = id !== section;
+  }
+}
+
+$("start").addEventListener("submit", async (event) => {
+  event.preventDefault();
+
+  const form = event.target.elements;
+  const tags = form.tags.value.split(/[,\s]+/).filter((tag) => tag);
+
+  localStorage.setItem("lang", form.lang.value);
+  localStorage.setItem("token", form.token.value.trim());
+
+  try {
+    quiz = await api("POST", "/quizzes", {
+      lang: form.lang.value.trim(),
+      tags,
+      choices: form.mode.value === "choice" ? 3 : 0,
+      articles: form.articles.value,
+      strict: form.strict.checked,
+    });
+  } catch (error) {
+    showError(error);
+    return;
+  }
+
+  showError(null);
+  position = 0;
+  show("quiz");
+  ask();
+});
+
+function ask() {
+  const question = quiz.questions[position];
+  const lines = question.text.split("\n");
+
+  $("level").textContent = question.level;
+  $("position").textContent = `${position + 1}/${quiz.questions.length}`;
+
+  // The level is shown apart, and choices as buttons
+  $("question").textContent = lines[0].replace(/^\[[^\]]*\]\s*/, "");
+
+  const choices = $("choices");
+  choices.replaceChildren();
+
+  for (const [i, choice] of (question.choices || []).entries()) {
+    const button = document.createElement("button");
+    button.type = "button";
+    button.textContent = choice;
+    button.addEventListener("click", () => answer(String(i + 1)));
+    choices.appendChild(button);
+  }
+
+  const input = $("answer").elements.answer;
+  const typed = !question.choices;
+
+  input.value = "";
+  input.hidden = !typed;
+  input.disabled = false;
+  $("answer").querySelector("button[type=submit]").hidden = !typed;
+  $("feedback").hidden = true;
+
+  if (typed) {
+    input.focus();
+  }
+}
+
+$("answer").addEventListener("submit", (event) => {
+  event.preventDefault();
+  answer(event.target.elements.answer.value);
+});
+
+async function answer(text) {
+  if (!$("feedback").hidden) {
+    return;
+  }
+
+  let graded;
+  try {
+    graded = await api("POST", `/quizzes/${quiz.id}/answers`, { question: position, answer: text });
+  } catch (error) {
+    showError(error);
+    return;
+  }
+
+  showError(null);
+
+  const result = $("result");
+  result.textContent = graded.correct ? `Correct (${graded.result})` : graded.result[0].toUpperCase() + graded.result.slice(1);
+  result.className = graded.correct ? "correct" : graded.result === "wrong" ? "wrong" : "almost";
+
+  $("expected").textContent = graded.pronunciation ? `${graded.expected} [${graded.pronunciation}]` : graded.expected;
+  $("answer").elements.answer.disabled = true;
+  $("feedback").hidden = false;
+
+  if (graded.summary) {
+    quiz.summary = graded.summary;
+  }
+
+  $("next").focus();
+}
+
+$("next").addEventListener("click", () => {
+  position++;
+
+  if (position < quiz.questions.length) {
+    ask();
+    return;
+  }
+
+  const summary = quiz.summary;
+  const counts = [`Total: ${summary.total}`, `Correct: ${summary.correct}`];
+
+  if (summary.near_misses) {
+    counts.push(`Almost: ${summary.near_misses}`);
+  }
+  if (summary.wrong_articles) {
+    counts.push(`Wrong article: ${summary.wrong_articles}`);
+  }
+  counts.push(`Mistakes: ${summary.mistakes}`);
+
+  // The first line of the summary text repeats the counts
+  $("counts").textContent = counts.join(", ");
+  $("mistakes").textContent = summary.text.split("\n").slice(1).join("\n");
+  show("summary");
+});
+
+$("again").addEventListener("click", () => show("start"));
+
+$("start").elements.lang.value = localStorage.getItem("lang") || "";
+$("start").elements.token.value = localStorage.getItem("token") || "";